@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/fatih/color"
+	"github.com/matheusflausino/setup-devops-cli/internal/bundle"
+	"github.com/matheusflausino/setup-devops-cli/internal/config"
+	"github.com/matheusflausino/setup-devops-cli/internal/installer"
+	"github.com/matheusflausino/setup-devops-cli/internal/pkgmgr"
+	"github.com/matheusflausino/setup-devops-cli/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var bundleCmd = &cobra.Command{
+	Use:   "bundle",
+	Short: "Gerenciar bundles offline",
+	Long: `Gerencia bundles offline: um arquivo .tar com todos os artefatos
+(binários, arquivos compactados, pacotes .deb/.rpm e chaves GPG) necessários
+para instalar as ferramentas sem acesso à rede.
+
+Para instalar a partir de um bundle use: setup-devops setup --from-bundle bundle.tar`,
+}
+
+var bundleCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Criar um bundle offline",
+	Long: `Baixa todos os artefatos das ferramentas selecionadas no perfil e grava
+um bundle com um manifesto contendo o checksum SHA256 de cada arquivo.
+
+O perfil é um arquivo YAML no mesmo formato de ~/.setup-devops.yaml, com as
+chaves "tools" e/ou "groups" selecionando as ferramentas (padrão: todas).
+
+Os pacotes .deb/.rpm são baixados com o gerenciador de pacotes do host, que
+precisa ter o mesmo sistema e arquitetura do alvo.`,
+	Example: `  setup-devops bundle create --profile team.yaml --os ubuntu --arch amd64 -o bundle.tar`,
+	RunE:    runBundleCreate,
+}
+
+var (
+	bundleProfile      string
+	bundleOS           string
+	bundleArch         string
+	bundleOutput       string
+	bundleSkipPackages bool
+)
+
+func init() {
+	rootCmd.AddCommand(bundleCmd)
+	bundleCmd.AddCommand(bundleCreateCmd)
+
+	bundleCreateCmd.Flags().StringVar(&bundleProfile, "profile", "", "Perfil com as ferramentas a incluir")
+	bundleCreateCmd.Flags().StringVar(&bundleOS, "os", "", "Sistema alvo: ubuntu, debian, centos, fedora, amazonlinux (padrão: sistema atual)")
+	bundleCreateCmd.Flags().StringVar(&bundleArch, "arch", runtime.GOARCH, "Arquitetura alvo: amd64, arm64")
+	bundleCreateCmd.Flags().StringVarP(&bundleOutput, "output", "o", "setup-devops-bundle.tar", "Arquivo de saída")
+	bundleCreateCmd.Flags().BoolVar(&bundleSkipPackages, "skip-packages", false, "Não incluir pacotes .deb/.rpm")
+}
+
+func runBundleCreate(cmd *cobra.Command, args []string) error {
+	if bundleProfile != "" {
+		if err := config.LoadProfile(bundleProfile); err != nil {
+			return fmt.Errorf("erro ao carregar perfil %s: %w", bundleProfile, err)
+		}
+	}
+
+	osType := utils.OSType(bundleOS)
+	if bundleOS == "" {
+		detected, err := utils.DetectOS()
+		if err != nil {
+			return fmt.Errorf("erro ao detectar sistema operacional: %w", err)
+		}
+		osType = detected
+	}
+
+	// Os pacotes do sistema precisam ser baixados com dependências (apt, dnf, yum)
+	pm, err := pkgmgr.New(pkgmgr.NameFor(&utils.OSInfo{Type: osType}))
+	if err != nil {
+		return fmt.Errorf("sistema alvo não suportado para bundles: %s", osType)
+	}
+	if _, ok := pm.(pkgmgr.Downloader); !ok {
+		return fmt.Errorf("sistema alvo não suportado para bundles: %s", osType)
+	}
+
+	if bundleArch != "amd64" && bundleArch != "arm64" {
+		return fmt.Errorf("arquitetura não suportada: %s", bundleArch)
+	}
+
+	tools, groups := config.ProfileTools()
+	if len(tools) == 0 && len(groups) == 0 {
+		groups = []string{"all"}
+	}
+
+	resolved, err := installer.ResolveTools(tools, groups)
+	if err != nil {
+		return err
+	}
+
+	var items []bundle.Item
+	for _, tool := range resolved {
+		toolItems, err := installer.Artifacts(tool, osType, bundleArch)
+		if err != nil {
+			return err
+		}
+		items = append(items, toolItems...)
+	}
+
+	color.Blue("📦 Criando bundle para %s/%s com %d ferramentas: %v", osType, bundleArch, len(resolved), resolved)
+
+	manifest, err := bundle.Create(bundle.CreateOptions{
+		OS:           osType,
+		Arch:         bundleArch,
+		Tools:        resolved,
+		Items:        items,
+		Output:       bundleOutput,
+		SkipPackages: bundleSkipPackages,
+	})
+	if err != nil {
+		return fmt.Errorf("erro ao criar bundle: %w", err)
+	}
+
+	var size int64
+	for _, artifact := range manifest.Artifacts {
+		size += artifact.Size
+	}
+
+	color.Green("✅ Bundle criado em %s (%d artefatos, %s)", bundleOutput, len(manifest.Artifacts), formatSize(size))
+	return nil
+}
+
+// runBundleSetup instala, sem acesso à rede, as ferramentas contidas no bundle
+func runBundleSetup(path string, osInfo *utils.OSInfo) error {
+	color.Blue("📦 Abrindo bundle %s e verificando checksums...", path)
+
+	b, err := bundle.Open(path)
+	if err != nil {
+		return fmt.Errorf("erro ao abrir bundle: %w", err)
+	}
+	defer b.Close()
+
+	if b.Manifest.OS != string(osInfo.Type) || b.Manifest.Arch != osInfo.Arch {
+		return fmt.Errorf("bundle criado para %s/%s, mas o sistema atual é %s/%s",
+			b.Manifest.OS, b.Manifest.Arch, osInfo.Type, osInfo.Arch)
+	}
+
+	bundle.Activate(b)
+	color.Green("✅ %d artefatos verificados", len(b.Manifest.Artifacts))
+
+	return installer.InstallTools(b.Manifest.Tools, osInfo)
+}
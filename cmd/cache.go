@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/matheusflausino/setup-devops-cli/internal/config"
+	"github.com/matheusflausino/setup-devops-cli/internal/download"
+	"github.com/matheusflausino/setup-devops-cli/internal/utils"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Gerenciar o cache de downloads",
+	Long: `Gerencia o cache local de downloads (padrão ~/.cache/setup-devops).
+
+Binários e arquivos baixados pelos instaladores são armazenados por checksum
+e reaproveitados em novas instalações, atualizações e na criação de bundles.
+O tamanho máximo é definido por "cache.maxSize" (padrão 2GB).`,
+}
+
+var cacheListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Listar os arquivos em cache",
+	RunE:  runCacheList,
+}
+
+var cachePruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Reduzir o cache ao tamanho máximo",
+	Long: `Remove os arquivos usados há mais tempo até que o cache caiba no tamanho
+máximo, além de arquivos que não são mais referenciados.`,
+	RunE: runCachePrune,
+}
+
+var cacheClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Apagar todo o cache",
+	RunE:  runCacheClear,
+}
+
+var cachePruneMaxSize string
+
+func init() {
+	rootCmd.AddCommand(cacheCmd)
+	cacheCmd.AddCommand(cacheListCmd, cachePruneCmd, cacheClearCmd)
+
+	cachePruneCmd.Flags().StringVar(&cachePruneMaxSize, "max-size", "", "Tamanho máximo após a limpeza (ex: 500MB; padrão: cache.maxSize)")
+}
+
+func runCacheList(cmd *cobra.Command, args []string) error {
+	entries, err := download.CacheList()
+	if err != nil {
+		return err
+	}
+
+	color.Blue("🗄️  Cache de downloads: %s", config.CacheDir())
+
+	if len(entries) == 0 {
+		color.Yellow("O cache está vazio")
+		return nil
+	}
+
+	var total int64
+	seen := make(map[string]bool)
+	for _, entry := range entries {
+		fmt.Printf("  %10s  %s  %s\n", formatSize(entry.Size), entry.LastUsed.Local().Format("2006-01-02 15:04"), entry.URL)
+		if !seen[entry.SHA256] {
+			seen[entry.SHA256] = true
+			total += entry.Size
+		}
+	}
+
+	maxSize, err := config.CacheMaxSize()
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	color.Green("📊 %d arquivos, %s de %s", len(entries), formatSize(total), formatSize(maxSize))
+	return nil
+}
+
+func runCachePrune(cmd *cobra.Command, args []string) error {
+	var maxSize int64
+	var err error
+	if cachePruneMaxSize != "" {
+		maxSize, err = config.ParseSize(cachePruneMaxSize)
+	} else {
+		maxSize, err = config.CacheMaxSize()
+	}
+	if err != nil {
+		return err
+	}
+
+	freed, err := download.CachePrune(maxSize)
+	if err != nil {
+		return fmt.Errorf("erro ao limpar o cache: %w", err)
+	}
+
+	color.Green("✅ %s liberados", formatSize(freed))
+	return nil
+}
+
+func runCacheClear(cmd *cobra.Command, args []string) error {
+	if !viper.GetBool("yes") {
+		confirmed, err := utils.ConfirmPrompt(fmt.Sprintf("Deseja apagar todo o cache em %s", config.CacheDir()))
+		if err != nil {
+			return fmt.Errorf("erro ao obter confirmação: %w", err)
+		}
+		if !confirmed {
+			color.Yellow("❌ Operação cancelada pelo usuário")
+			return nil
+		}
+	}
+
+	if err := download.CacheClear(); err != nil {
+		return fmt.Errorf("erro ao apagar o cache: %w", err)
+	}
+
+	color.Green("✅ Cache apagado")
+	return nil
+}
+
+// formatSize formata um tamanho em bytes para leitura
+func formatSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(size)/float64(div), "KMGT"[exp])
+}
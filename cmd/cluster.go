@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/matheusflausino/setup-devops-cli/internal/cluster"
+	"github.com/matheusflausino/setup-devops-cli/internal/config"
+	"github.com/matheusflausino/setup-devops-cli/internal/installer"
+	"github.com/matheusflausino/setup-devops-cli/internal/utils"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var clusterCmd = &cobra.Command{
+	Use:   "cluster",
+	Short: "Gerenciar um cluster Kubernetes local",
+	Long: `Cria e remove um cluster Kubernetes local com kind, minikube ou k3d,
+para usar com kubectl, Helm e K9s em uma máquina nova.
+
+Os nós do cluster rodam como containers, por isso o Docker (grupo essentials)
+precisa estar instalado e em execução. A ferramenta escolhida e o kubectl são
+instalados se ainda não estiverem. O contexto do cluster é gravado no
+kubeconfig e selecionado como atual.`,
+}
+
+var clusterUpCmd = &cobra.Command{
+	Use:   "up",
+	Short: "Criar o cluster local",
+	Example: `  setup-devops cluster up
+  setup-devops cluster up --tool k3d --name dev`,
+	Args: cobra.NoArgs,
+	RunE: runClusterUp,
+}
+
+var clusterDownCmd = &cobra.Command{
+	Use:     "down",
+	Short:   "Remover o cluster local",
+	Example: `  setup-devops cluster down --tool k3d --name dev`,
+	Args:    cobra.NoArgs,
+	RunE:    runClusterDown,
+}
+
+func init() {
+	rootCmd.AddCommand(clusterCmd)
+	clusterCmd.AddCommand(clusterUpCmd, clusterDownCmd)
+
+	clusterCmd.PersistentFlags().String("tool", "", "Ferramenta do cluster: kind, minikube ou k3d (padrão: a já instalada ou kind)")
+	clusterCmd.PersistentFlags().String("name", config.DefaultClusterName, "Nome do cluster")
+	_ = viper.BindPFlag("cluster.tool", clusterCmd.PersistentFlags().Lookup("tool"))
+	_ = viper.BindPFlag("cluster.name", clusterCmd.PersistentFlags().Lookup("name"))
+}
+
+func runClusterUp(cmd *cobra.Command, args []string) error {
+	if err := checkRoot(); err != nil {
+		return err
+	}
+
+	osInfo, err := utils.GetOSInfo()
+	if err != nil {
+		return fmt.Errorf("erro ao detectar sistema operacional: %w", err)
+	}
+
+	// Os nós do cluster são containers
+	if !installer.IsToolInstalled("docker") {
+		return fmt.Errorf("o Docker é necessário para o cluster local; instale-o com: setup-devops install docker")
+	}
+	if err := utils.RunCommand("docker", "info"); err != nil {
+		return fmt.Errorf("o daemon do Docker não está acessível; inicie-o e verifique se o usuário está no grupo docker")
+	}
+
+	provider, err := cluster.Get(clusterTool())
+	if err != nil {
+		return err
+	}
+
+	for _, tool := range []string{provider.Name, "kubectl"} {
+		if !installer.IsToolInstalled(tool) {
+			if err := installer.InstallTool(tool, osInfo); err != nil {
+				return fmt.Errorf("erro ao instalar %s: %w", tool, err)
+			}
+		}
+	}
+
+	name := config.ClusterName()
+	color.Blue("⏳ Criando o cluster %s com %s (pode levar alguns minutos)...", name, provider.Name)
+	if err := provider.Up(name); err != nil {
+		return err
+	}
+	color.Green("✅ Cluster %s criado; contexto atual: %s", name, provider.Context(name))
+
+	nodes, err := provider.Verify(name)
+	if err != nil {
+		return err
+	}
+	fmt.Print(nodes)
+	return nil
+}
+
+func runClusterDown(cmd *cobra.Command, args []string) error {
+	provider, err := cluster.Get(clusterTool())
+	if err != nil {
+		return err
+	}
+
+	name := config.ClusterName()
+	color.Blue("🗑️  Removendo o cluster %s (%s)...", name, provider.Name)
+	if err := provider.Down(name); err != nil {
+		return err
+	}
+
+	color.Green("✅ Cluster %s removido", name)
+	return nil
+}
+
+// clusterTool retorna a ferramenta configurada ou, sem configuração, a
+// primeira já instalada (kind por padrão)
+func clusterTool() string {
+	if tool := config.ClusterTool(); tool != "" {
+		return tool
+	}
+
+	for _, tool := range installer.GetLocalClusterTools() {
+		if installer.IsToolInstalled(tool) {
+			return tool
+		}
+	}
+	return "kind"
+}
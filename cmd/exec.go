@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+
+	"github.com/fatih/color"
+	"github.com/matheusflausino/setup-devops-cli/internal/installer"
+	"github.com/spf13/cobra"
+)
+
+// execCmd é chamado pelos shims: resolve a versão fixada pelo projeto e
+// substitui o processo pela ferramenta, repassando os argumentos intactos
+var execCmd = &cobra.Command{
+	Use:                "exec TOOL [ARGS...]",
+	Short:              "Executar a versão de uma ferramenta fixada pelo projeto",
+	Hidden:             true,
+	DisableFlagParsing: true,
+	SilenceUsage:       true,
+	SilenceErrors:      true,
+	Args:               cobra.MinimumNArgs(1),
+	RunE:               runExec,
+}
+
+func init() {
+	rootCmd.AddCommand(execCmd)
+}
+
+func runExec(cmd *cobra.Command, args []string) error {
+	tool := args[0]
+
+	// A saída da instalação no primeiro uso vai para o stderr, para não se
+	// misturar à saída da ferramenta (ex: terraform output -json)
+	stdout := os.Stdout
+	os.Stdout = os.Stderr
+	color.Output = os.Stderr
+
+	dir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("erro ao obter o diretório atual: %w", err)
+	}
+
+	resolution, err := installer.ResolveTool(tool, dir)
+	if err != nil {
+		return err
+	}
+
+	os.Stdout = stdout
+	argv := append([]string{tool}, args[1:]...)
+	if err := syscall.Exec(resolution.Path, argv, os.Environ()); err != nil {
+		return fmt.Errorf("erro ao executar %s: %w", resolution.Path, err)
+	}
+	return nil
+}
@@ -17,7 +17,12 @@ var installCmd = &cobra.Command{
 
 Ferramentas disponíveis:
 • Essenciais: docker, git, net-tools
-• Cloud & DevOps: terraform, aws-cli, kubectl, watch, helm, helmfile, k9s`,
+• Cloud & DevOps: terraform, aws-cli, kubectl, watch, helm, helmfile, k9s
+• Opcionais (grupo cloud): gcloud, az
+• Opcionais (grupo local-clusters): kind, minikube, k3d
+• Opcionais (grupo kubernetes-extras): kubectx, kubens, stern, kustomize, krew
+• Opcionais (grupo iac): terragrunt, tflint, trivy, terraform-docs
+• Opcionais (grupo hashicorp, com o terraform): vault, packer, consul`,
 	Args: cobra.ExactArgs(1),
 	RunE: runInstall,
 }
@@ -31,41 +36,36 @@ func runInstall(cmd *cobra.Command, args []string) error {
 	tool := args[0]
 
 	// Verificar se está rodando como root
-	if utils.IsRoot() {
-		return fmt.Errorf("este comando não deve ser executado como root")
+	if err := checkRoot(); err != nil {
+		return err
 	}
 
 	// Detectar sistema operacional
-	osType, err := utils.DetectOS()
+	osInfo, err := utils.GetOSInfo()
 	if err != nil {
 		return fmt.Errorf("erro ao detectar sistema operacional: %w", err)
 	}
 
 	// Verificar se a ferramenta é válida
-	allTools := installer.GetAllTools()
-	toolValid := false
-	for _, t := range allTools {
-		if t == tool {
-			toolValid = true
-			break
-		}
-	}
-
-	if !toolValid {
+	if !installer.IsValidTool(tool) {
 		color.Red("❌ Ferramenta não reconhecida: %s", tool)
 		color.Yellow("Ferramentas disponíveis:")
 		color.Yellow("• Essenciais: %v", installer.GetEssentialTools())
 		color.Yellow("• Cloud & DevOps: %v", installer.GetCloudDevOpsTools())
+		color.Yellow("• Opcionais: %v", installer.GetOptionalTools())
 		return fmt.Errorf("ferramenta não reconhecida: %s", tool)
 	}
 
 	// Verificar pré-requisitos
-	if err := installer.CheckPrerequisites(osType); err != nil {
+	if err := installer.CheckPrerequisites(osInfo); err != nil {
 		return fmt.Errorf("erro nos pré-requisitos: %w", err)
 	}
 
-	// Verificar se já está instalado
+	// Verificar se já está instalado (os plugins ainda são atualizados)
 	if installer.IsToolInstalled(tool) {
+		if installer.HasComponents(tool) {
+			return installer.InstallTool(tool, osInfo)
+		}
 		color.Yellow("⚠️  %s já está instalado", tool)
 		return nil
 	}
@@ -85,7 +85,7 @@ func runInstall(cmd *cobra.Command, args []string) error {
 
 	// Instalar a ferramenta
 	color.Green("🔧 Instalando %s...", tool)
-	if err := installer.InstallTool(tool, osType); err != nil {
+	if err := installer.InstallTool(tool, osInfo); err != nil {
 		return fmt.Errorf("erro ao instalar %s: %w", tool, err)
 	}
 
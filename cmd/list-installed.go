@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/matheusflausino/setup-devops-cli/internal/installer"
+	"github.com/spf13/cobra"
+)
+
+var listInstalledCmd = &cobra.Command{
+	Use:   "list-installed [TOOL]",
+	Short: "Listar as versões instaladas de uma ferramenta",
+	Long: `Lista as versões de terraform, kubectl ou helm instaladas lado a lado em
+<prefix>/opt, marcando a versão ativa. Sem ferramenta, lista todas.`,
+	Example: `  setup-devops list-installed terraform`,
+	Args:    cobra.MaximumNArgs(1),
+	RunE:    runListInstalled,
+}
+
+func init() {
+	rootCmd.AddCommand(listInstalledCmd)
+}
+
+func runListInstalled(cmd *cobra.Command, args []string) error {
+	tools := installer.VersionedTools
+	if len(args) == 1 {
+		tools = args
+	}
+
+	for _, tool := range tools {
+		versions, err := installer.InstalledVersions(tool)
+		if err != nil {
+			return err
+		}
+
+		color.Cyan("📦 %s:", tool)
+		if len(versions) == 0 {
+			fmt.Println("  nenhuma versão instalada no prefixo")
+		}
+		for _, v := range versions {
+			if v.Active {
+				fmt.Printf("  %s %s (ativa)\n", color.GreenString("*"), v.Version)
+			} else {
+				fmt.Printf("    %s\n", v.Version)
+			}
+		}
+		if path := installer.ShadowingBinary(tool); path != "" {
+			if version := installer.ToolVersion(tool); version != "" {
+				path = fmt.Sprintf("%s (%s)", path, version)
+			}
+			color.Yellow("  ⚠️  O PATH usa %s, fora do prefixo", path)
+		}
+	}
+	return nil
+}
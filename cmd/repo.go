@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/matheusflausino/setup-devops-cli/internal/installer"
+	"github.com/matheusflausino/setup-devops-cli/internal/pkgmgr"
+	"github.com/matheusflausino/setup-devops-cli/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var repoCmd = &cobra.Command{
+	Use:   "repo",
+	Short: "Gerenciar repositórios de pacotes de terceiros",
+	Long: `Gerencia os repositórios de pacotes dos fornecedores (Docker, HashiCorp)
+usados pelas ferramentas.
+
+A chave de assinatura é baixada, conferida com o fingerprint fixado e gravada
+em /etc/apt/keyrings (apt) ou importada no rpm (dnf, yum, zypper). O
+repositório é gravado como /etc/apt/sources.list.d/<nome>.sources (deb822) ou
+/etc/yum.repos.d/<nome>.repo. Executar novamente não altera nenhum arquivo.`,
+}
+
+var repoAddCmd = &cobra.Command{
+	Use:     "add [TOOL]",
+	Short:   "Configurar o repositório de uma ferramenta",
+	Example: `  setup-devops repo add docker`,
+	Args:    cobra.ExactArgs(1),
+	RunE:    runRepoAdd,
+}
+
+var repoRemoveCmd = &cobra.Command{
+	Use:     "remove [TOOL]",
+	Short:   "Remover o repositório e a chave de uma ferramenta",
+	Example: `  setup-devops repo remove terraform`,
+	Args:    cobra.ExactArgs(1),
+	RunE:    runRepoRemove,
+}
+
+func init() {
+	rootCmd.AddCommand(repoCmd)
+	repoCmd.AddCommand(repoAddCmd, repoRemoveCmd)
+}
+
+// toolRepository resolve o repositório da ferramenta e o gerenciador de pacotes do sistema
+func toolRepository(tool string) (*pkgmgr.Repository, pkgmgr.PackageManager, error) {
+	if err := checkRoot(); err != nil {
+		return nil, nil, err
+	}
+
+	osInfo, err := utils.GetOSInfo()
+	if err != nil {
+		return nil, nil, fmt.Errorf("erro ao detectar sistema operacional: %w", err)
+	}
+
+	repo, err := installer.ToolRepository(tool, osInfo)
+	if err != nil {
+		return nil, nil, err
+	}
+	if repo == nil {
+		return nil, nil, fmt.Errorf("%s não usa repositório de terceiros em %s", tool, osInfo)
+	}
+
+	pm, err := pkgmgr.ForOS(osInfo)
+	if err != nil {
+		return nil, nil, err
+	}
+	return repo, pm, nil
+}
+
+func runRepoAdd(cmd *cobra.Command, args []string) error {
+	repo, pm, err := toolRepository(args[0])
+	if err != nil {
+		return err
+	}
+
+	color.Blue("📦 Configurando repositório %s (%s)...", repo.Name, pm.Name())
+	if err := pm.AddRepository(*repo); err != nil {
+		return fmt.Errorf("erro ao adicionar repositório %s: %w", repo.Name, err)
+	}
+
+	color.Green("✅ Repositório %s configurado", repo.Name)
+	return nil
+}
+
+func runRepoRemove(cmd *cobra.Command, args []string) error {
+	repo, pm, err := toolRepository(args[0])
+	if err != nil {
+		return err
+	}
+
+	color.Blue("🗑️  Removendo repositório %s (%s)...", repo.Name, pm.Name())
+	if err := pm.RemoveRepository(*repo); err != nil {
+		return fmt.Errorf("erro ao remover repositório %s: %w", repo.Name, err)
+	}
+
+	color.Green("✅ Repositório %s removido", repo.Name)
+	return nil
+}
@@ -5,6 +5,9 @@ import (
 	"os"
 
 	"github.com/fatih/color"
+	"github.com/matheusflausino/setup-devops-cli/internal/config"
+	"github.com/matheusflausino/setup-devops-cli/internal/installer"
+	"github.com/matheusflausino/setup-devops-cli/internal/utils"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -26,7 +29,8 @@ Ferramentas suportadas:
 • Essenciais: Docker, Git, net-tools
 • Cloud & DevOps: Terraform, AWS CLI, kubectl, watch, Helm, Helmfile, K9s
 
-Sistemas suportados: Ubuntu 20.04+, CentOS/RHEL 8+, macOS 12+`,
+Sistemas suportados: Ubuntu 20.04+, Debian 11+, CentOS/RHEL 8+, Fedora 38+,
+Amazon Linux 2/2023, openSUSE, Arch Linux, Alpine 3.17+ e macOS 12+`,
 	Version: version,
 	Run: func(cmd *cobra.Command, args []string) {
 		// Se o flag --version foi usado, mostrar informações de versão
@@ -61,10 +65,23 @@ func init() {
 	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "verbose output")
 	rootCmd.PersistentFlags().BoolP("yes", "y", false, "skip confirmation prompts")
 	rootCmd.PersistentFlags().Bool("version", false, "show version information")
+	rootCmd.PersistentFlags().Bool("force", false, "continue on unsupported operating system versions")
+	rootCmd.PersistentFlags().String("prefix", "", "install prefix for binaries (default is /usr/local, env SETUP_DEVOPS_PREFIX)")
+	rootCmd.PersistentFlags().Bool("container", false, "container mode: allow running as root without sudo (auto-detected)")
+	rootCmd.PersistentFlags().String("escalation", "auto", "privilege escalation: auto, sudo, doas or none")
+	rootCmd.PersistentFlags().Duration("lock-timeout", 0, "how long to wait for the package manager lock (default 5m)")
 
 	// Bind flags to viper
 	_ = viper.BindPFlag("verbose", rootCmd.PersistentFlags().Lookup("verbose"))
 	_ = viper.BindPFlag("yes", rootCmd.PersistentFlags().Lookup("yes"))
+	_ = viper.BindPFlag("force", rootCmd.PersistentFlags().Lookup("force"))
+	_ = viper.BindPFlag("prefix", rootCmd.PersistentFlags().Lookup("prefix"))
+	_ = viper.BindEnv("prefix", "SETUP_DEVOPS_PREFIX")
+	_ = viper.BindPFlag("container", rootCmd.PersistentFlags().Lookup("container"))
+	_ = viper.BindEnv("container", "SETUP_DEVOPS_CONTAINER")
+	_ = viper.BindPFlag("escalation", rootCmd.PersistentFlags().Lookup("escalation"))
+	_ = viper.BindPFlag("packages.lockTimeout", rootCmd.PersistentFlags().Lookup("lock-timeout"))
+	_ = viper.BindEnv("shims.autoInstall", "SETUP_DEVOPS_AUTO_INSTALL")
 
 	// Configurar cores
 	color.NoColor = false
@@ -76,6 +93,21 @@ func showVersionInfo() {
 	fmt.Printf("Build Date: %s\n", date)
 }
 
+// checkRoot impede a execução como root fora de containers, onde a CLI
+// deve rodar como o usuário que vai usar as ferramentas
+func checkRoot() error {
+	if !utils.IsRoot() || containerMode() {
+		return nil
+	}
+	return fmt.Errorf("este comando não deve ser executado como root (em containers use --container)")
+}
+
+// containerMode indica se a CLI roda em um container, detectado
+// automaticamente ou forçado com --container
+func containerMode() bool {
+	return config.Container() || utils.InContainer()
+}
+
 // initConfig reads in config file and ENV variables if set.
 func initConfig() {
 	if cfgFile != "" {
@@ -94,8 +126,12 @@ func initConfig() {
 
 	viper.AutomaticEnv() // read in environment variables that match
 
-	// If a config file is found, read it in.
-	if err := viper.ReadInConfig(); err == nil {
+	// If a config file is found, read it in. Shims must not add any output
+	// to the tool they run.
+	if err := viper.ReadInConfig(); err == nil && os.Getenv(installer.ShimEnv) == "" {
 		fmt.Fprintln(os.Stderr, "Using config file:", viper.ConfigFileUsed())
 	}
+
+	// The shims dispatch to the real tools, so they never count as installed
+	utils.IgnorePathDir(config.ShimsDir())
 }
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/fatih/color"
 	"github.com/matheusflausino/setup-devops-cli/internal/installer"
@@ -14,123 +15,145 @@ var setupCmd = &cobra.Command{
 	Use:   "setup",
 	Short: "Instalar ferramentas DevOps",
 	Long: `Instala ferramentas DevOps no sistema. Pode ser usado de forma interativa
-ou automática com a flag --yes.`,
+ou automática com a flag --yes, que sem --type instala todas as ferramentas e
+com --type instala o grupo escolhido sem confirmações.`,
 	RunE: runSetup,
 }
 
 var (
-	setupType string
+	setupType       string
+	setupFromBundle string
 )
 
 func init() {
 	rootCmd.AddCommand(setupCmd)
 
-	setupCmd.Flags().StringVarP(&setupType, "type", "t", "interactive", "Tipo de setup: interactive, essentials, cloud-devops, all")
+	setupCmd.Flags().StringVarP(&setupType, "type", "t", "interactive", "Tipo de setup: "+strings.Join(setupTypes(), ", "))
 	setupCmd.Flags().BoolP("yes", "y", false, "Skip confirmation prompts")
+	setupCmd.Flags().StringVar(&setupFromBundle, "from-bundle", "", "Instalar sem acesso à rede a partir de um bundle offline")
+}
+
+// setupTypes retorna os valores aceitos por --type: interactive e os grupos do catálogo
+func setupTypes() []string {
+	types := []string{"interactive"}
+	for _, group := range installer.GetToolGroups() {
+		types = append(types, group.Name)
+	}
+	return types
 }
 
 func runSetup(cmd *cobra.Command, args []string) error {
 	// Verificar se está rodando como root
-	if utils.IsRoot() {
-		return fmt.Errorf("este comando não deve ser executado como root")
+	if err := checkRoot(); err != nil {
+		return err
 	}
 
 	// Detectar sistema operacional
-	osType, err := utils.DetectOS()
+	osInfo, err := utils.GetOSInfo()
 	if err != nil {
 		return fmt.Errorf("erro ao detectar sistema operacional: %w", err)
 	}
 
 	color.Blue("🚀 Setup DevOps Tools")
-	color.Blue("Sistema operacional detectado: %s", string(osType))
+	color.Blue("Sistema operacional detectado: %s", osInfo.String())
 
 	// Verificar pré-requisitos
-	if err := installer.CheckPrerequisites(osType); err != nil {
+	if err := installer.CheckPrerequisites(osInfo); err != nil {
 		return fmt.Errorf("erro nos pré-requisitos: %w", err)
 	}
 
+	// Instalação offline a partir de um bundle
+	if setupFromBundle != "" {
+		return runBundleSetup(setupFromBundle, osInfo)
+	}
+
 	// Determinar tipo de setup
 	yes := viper.GetBool("yes") || cmd.Flag("yes").Changed
 	setupMode := setupType
 
-	if yes {
-		// Setup automático
+	if yes && setupMode == "interactive" {
+		// Sem prompts e sem --type, o setup automático instala todas as ferramentas
 		color.Yellow("⚠️  Executando setup automático (todas as ferramentas)")
-		return installer.InstallAll(osType)
+		return installer.InstallAll(osInfo)
 	}
 
-	// Setup interativo
-	switch setupMode {
-	case "interactive":
-		return runInteractiveSetup(osType)
+	if setupMode == "interactive" {
+		return runInteractiveSetup(osInfo)
+	}
+	return installGroup(setupMode, osInfo)
+}
+
+// installGroup instala um grupo do catálogo (essentials, cloud-devops,
+// cloud, local-clusters, kubernetes-extras, iac, hashicorp, all)
+func installGroup(name string, osInfo *utils.OSInfo) error {
+	switch name {
 	case "essentials":
 		color.Green("📦 Instalando ferramentas essenciais...")
-		return installer.InstallEssentials(osType)
+		return installer.InstallEssentials(osInfo)
 	case "cloud-devops":
 		color.Green("☁️  Instalando ferramentas Cloud & DevOps...")
-		return installer.InstallCloudDevOps(osType)
+		return installer.InstallCloudDevOps(osInfo)
 	case "all":
 		color.Green("🔧 Instalando todas as ferramentas...")
-		return installer.InstallAll(osType)
+		return installer.InstallAll(osInfo)
 	default:
-		return fmt.Errorf("tipo de setup inválido: %s", setupMode)
+		tools, err := installer.ResolveTools(nil, []string{name})
+		if err != nil {
+			return fmt.Errorf("tipo de setup inválido: %s", name)
+		}
+		color.Green("🔧 Instalando o grupo %s...", name)
+		return installer.InstallTools(tools, osInfo)
 	}
 }
 
-func runInteractiveSetup(osType utils.OSType) error {
-	for {
-		showSetupMenu()
+// setupMenuOptions monta as opções do menu a partir dos grupos do catálogo,
+// seguidas da instalação individual e da saída
+func setupMenuOptions(groups []installer.ToolGroup) []string {
+	options := make([]string, 0, len(groups)+2)
+	for _, group := range groups {
+		options = append(options, group.Description)
+	}
+	return append(options, "Instalar ferramenta específica", "Sair")
+}
 
-		choice, err := utils.GetUserChoice("Escolha uma opção", []string{
-			"Ferramentas Essenciais (Docker, Git, net-tools)",
-			"Ferramentas Cloud & DevOps (Terraform, AWS CLI, kubectl, etc.)",
-			"Todas as ferramentas",
-			"Instalar ferramenta específica",
-			"Sair",
-		})
+func runInteractiveSetup(osInfo *utils.OSInfo) error {
+	groups := installer.GetToolGroups()
+	options := setupMenuOptions(groups)
 
+	for {
+		showSetupMenu(options)
+
+		choice, err := utils.GetUserChoice("Escolha uma opção", options)
 		if err != nil {
 			return err
 		}
 
-		switch choice {
-		case 1:
-			color.Green("📦 Instalando ferramentas essenciais...")
-			if err := installer.InstallEssentials(osType); err != nil {
-				color.Red("❌ Erro ao instalar ferramentas essenciais: %v", err)
-			}
-		case 2:
-			color.Green("☁️  Instalando ferramentas Cloud & DevOps...")
-			if err := installer.InstallCloudDevOps(osType); err != nil {
-				color.Red("❌ Erro ao instalar ferramentas Cloud & DevOps: %v", err)
+		switch {
+		case choice <= len(groups):
+			group := groups[choice-1]
+			if err := installGroup(group.Name, osInfo); err != nil {
+				color.Red("❌ Erro ao instalar o grupo %s: %v", group.Name, err)
 			}
-		case 3:
-			color.Green("🔧 Instalando todas as ferramentas...")
-			if err := installer.InstallAll(osType); err != nil {
-				color.Red("❌ Erro ao instalar todas as ferramentas: %v", err)
-			}
-		case 4:
-			if err := runIndividualToolSetup(osType); err != nil {
+		case choice == len(groups)+1:
+			if err := runIndividualToolSetup(osInfo); err != nil {
 				color.Red("❌ Erro no setup individual: %v", err)
 			}
-		case 5:
+		default:
 			color.Green("✅ Setup concluído!")
 			return nil
 		}
 	}
 }
 
-func showSetupMenu() {
+func showSetupMenu(options []string) {
 	color.Cyan("\n=== Setup DevOps Tools ===")
-	fmt.Println("1. Ferramentas Essenciais (Docker, Git, net-tools)")
-	fmt.Println("2. Ferramentas Cloud & DevOps (Terraform, AWS CLI, kubectl, etc.)")
-	fmt.Println("3. Todas as ferramentas")
-	fmt.Println("4. Instalar ferramenta específica")
-	fmt.Println("5. Sair")
+	for i, option := range options {
+		fmt.Printf("%d. %s\n", i+1, option)
+	}
 	color.Cyan("========================\n")
 }
 
-func runIndividualToolSetup(osType utils.OSType) error {
+func runIndividualToolSetup(osInfo *utils.OSInfo) error {
 	tools := installer.GetAllTools()
 
 	for {
@@ -148,7 +171,7 @@ func runIndividualToolSetup(osType utils.OSType) error {
 		if choice >= 1 && choice <= len(tools) {
 			tool := tools[choice-1]
 			color.Green("🔧 Instalando %s...", tool)
-			if err := installer.InstallTool(tool, osType); err != nil {
+			if err := installer.InstallTool(tool, osInfo); err != nil {
 				color.Red("❌ Erro ao instalar %s: %v", tool, err)
 			}
 		}
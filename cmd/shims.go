@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/matheusflausino/setup-devops-cli/internal/config"
+	"github.com/matheusflausino/setup-devops-cli/internal/installer"
+	"github.com/spf13/cobra"
+)
+
+var shimsCmd = &cobra.Command{
+	Use:   "shims",
+	Short: "Gerenciar os shims de versões por projeto",
+	Long: `Gerencia os shims de terraform, kubectl e helm (padrão
+~/.local/share/setup-devops/shims, configurável em "shims.dir").
+
+Com o diretório dos shims no PATH antes de <prefix>/bin, cada comando usa a
+versão fixada pelo repositório em um .setup-devops.yaml (seção versions) ou
+.tool-versions, procurado do diretório atual para cima. Sem versão fixada,
+vale a versão ativa em <prefix>/bin. Com "shims.autoInstall: true" (ou
+SETUP_DEVOPS_AUTO_INSTALL=true), a versão fixada é instalada no primeiro uso.`,
+}
+
+var shimsInstallCmd = &cobra.Command{
+	Use:   "install",
+	Short: "Criar os shims",
+	RunE:  runShimsInstall,
+}
+
+var shimsRemoveCmd = &cobra.Command{
+	Use:   "remove",
+	Short: "Remover os shims",
+	RunE:  runShimsRemove,
+}
+
+var whichCmd = &cobra.Command{
+	Use:   "which TOOL",
+	Short: "Mostrar qual versão de uma ferramenta é usada no diretório atual",
+	Long: `Mostra a versão de terraform, kubectl ou helm que os shims executam no
+diretório atual e de onde ela vem (arquivo do projeto, versão ativa em
+<prefix>/bin ou executável do sistema).`,
+	Example: `  setup-devops which terraform`,
+	Args:    cobra.ExactArgs(1),
+	RunE:    runWhich,
+}
+
+func init() {
+	rootCmd.AddCommand(shimsCmd, whichCmd)
+	shimsCmd.AddCommand(shimsInstallCmd, shimsRemoveCmd)
+}
+
+func runShimsInstall(cmd *cobra.Command, args []string) error {
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("erro ao localizar o executável da CLI: %w", err)
+	}
+
+	if err := installer.InstallShims(executable); err != nil {
+		return err
+	}
+	color.Green("✅ Shims criados em %s", config.ShimsDir())
+
+	if !installer.ShimsInPath() {
+		color.Yellow("⚠️  Adicione o diretório dos shims ao PATH, antes de %s/bin:", config.Prefix())
+		fmt.Printf("  export PATH=\"%s:$PATH\"\n", config.ShimsDir())
+	}
+	return nil
+}
+
+func runShimsRemove(cmd *cobra.Command, args []string) error {
+	if err := installer.RemoveShims(); err != nil {
+		return err
+	}
+	color.Green("✅ Shims removidos de %s", config.ShimsDir())
+	return nil
+}
+
+func runWhich(cmd *cobra.Command, args []string) error {
+	dir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("erro ao obter o diretório atual: %w", err)
+	}
+
+	resolution, err := installer.ResolveTool(args[0], dir)
+	if err != nil {
+		return err
+	}
+
+	version := resolution.Version
+	if version == "" {
+		version = "versão desconhecida"
+	}
+	fmt.Printf("%s %s\n", resolution.Tool, version)
+	fmt.Printf("  executável: %s\n", resolution.Path)
+	fmt.Printf("  origem:     %s\n", resolution.Source)
+	return nil
+}
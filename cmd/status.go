@@ -30,8 +30,18 @@ func runStatus(cmd *cobra.Command, args []string) error {
 	}
 
 	color.Blue("🚀 Setup DevOps Tools - Status")
-	color.Blue("Sistema: %s (%s)", osInfo["type"], osInfo["version"])
-	color.Blue("Arquitetura: %s", osInfo["arch"])
+	color.Blue("Sistema: %s (%s %s)", osInfo.String(), osInfo.Type, osInfo.VersionID)
+	color.Blue("Arquitetura: %s", osInfo.Arch)
+	if osInfo.WSL > 0 {
+		color.Blue("Ambiente: WSL%d (%s)", osInfo.WSL, utils.WSLDistro())
+		if utils.DockerDesktopIntegration() {
+			color.Blue("Docker: integração WSL do Docker Desktop")
+		}
+		installer.WarnWindowsShadowedTools()
+	}
+	if err := osInfo.CheckMinimumVersion(); err != nil {
+		color.Yellow("⚠️  %v", err)
+	}
 	fmt.Println()
 
 	// Verificar ferramentas essenciais
@@ -48,6 +58,36 @@ func runStatus(cmd *cobra.Command, args []string) error {
 
 	fmt.Println()
 
+	// Verificar CLIs de nuvem opcionais
+	color.Cyan("🌐 CLIs de nuvem (opcionais):")
+	checkToolsStatus(installer.GetOptionalCloudTools())
+
+	fmt.Println()
+
+	// Verificar ferramentas de clusters locais opcionais
+	color.Cyan("🧪 Clusters Kubernetes locais (opcionais):")
+	checkToolsStatus(installer.GetLocalClusterTools())
+
+	fmt.Println()
+
+	// Verificar ferramentas de produtividade para Kubernetes
+	color.Cyan("🧰 Kubernetes extras (opcionais):")
+	checkToolsStatus(installer.GetKubernetesExtrasTools())
+
+	fmt.Println()
+
+	// Verificar produtos opcionais da HashiCorp
+	color.Cyan("🔐 HashiCorp (opcionais):")
+	checkToolsStatus(installer.GetHashicorpTools())
+
+	fmt.Println()
+
+	// Verificar ferramentas do ecossistema Terraform
+	color.Cyan("🏗️  IaC (opcionais):")
+	checkToolsStatus(installer.GetIaCTools())
+
+	fmt.Println()
+
 	// Resumo
 	allTools := installer.GetAllTools()
 	installedCount := 0
@@ -78,6 +118,23 @@ func checkToolsStatus(tools []string) {
 			statusColor = color.GreenString
 		}
 
-		fmt.Printf("  %s %s\n", statusColor(status), tool)
+		if provider := installer.ToolProvider(tool); provider != "" {
+			fmt.Printf("  %s %s (%s)\n", statusColor(status), tool, provider)
+		} else if version := installer.ToolVersion(tool); version != "" {
+			fmt.Printf("  %s %s %s\n", statusColor(status), tool, version)
+		} else {
+			fmt.Printf("  %s %s\n", statusColor(status), tool)
+		}
+
+		for _, component := range installer.ToolComponents(tool) {
+			switch {
+			case component.Version == "":
+				fmt.Printf("      %s %s\n", color.RedString("❌"), component.Name)
+			case component.Managed != "" && component.Version != component.Managed:
+				fmt.Printf("      %s %s %s (gerenciada: %s)\n", color.YellowString("⚠️"), component.Name, component.Version, component.Managed)
+			default:
+				fmt.Printf("      %s %s %s\n", color.GreenString("✅"), component.Name, component.Version)
+			}
+		}
 	}
 }
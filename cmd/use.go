@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/matheusflausino/setup-devops-cli/internal/installer"
+	"github.com/matheusflausino/setup-devops-cli/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var useCmd = &cobra.Command{
+	Use:   "use TOOL VERSION",
+	Short: "Ativar uma versão de uma ferramenta",
+	Long: `Ativa uma versão de terraform, kubectl ou helm em <prefix>/bin.
+
+As versões ficam lado a lado em <prefix>/opt/<ferramenta>/<versão> e a troca
+apenas substitui o symlink em <prefix>/bin. Se a versão ainda não estiver
+instalada, ela é baixada das releases oficiais antes de ser ativada.`,
+	Example: `  setup-devops use terraform 1.5.7
+  setup-devops use kubectl v1.29.4`,
+	Args: cobra.ExactArgs(2),
+	RunE: runUse,
+}
+
+func init() {
+	rootCmd.AddCommand(useCmd)
+}
+
+func runUse(cmd *cobra.Command, args []string) error {
+	tool, version := args[0], args[1]
+
+	if err := checkRoot(); err != nil {
+		return err
+	}
+
+	osInfo, err := utils.GetOSInfo()
+	if err != nil {
+		return fmt.Errorf("erro ao detectar sistema operacional: %w", err)
+	}
+
+	return installer.UseVersion(tool, version, osInfo)
+}
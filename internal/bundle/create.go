@@ -0,0 +1,262 @@
+package bundle
+
+import (
+	"archive/tar"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/matheusflausino/setup-devops-cli/internal/download"
+	"github.com/matheusflausino/setup-devops-cli/internal/pkgmgr"
+	"github.com/matheusflausino/setup-devops-cli/internal/utils"
+)
+
+// CreateOptions configura a criação de um bundle
+type CreateOptions struct {
+	OS     utils.OSType
+	Arch   string
+	Tools  []string
+	Items  []Item
+	Output string
+	// SkipPackages ignora os pacotes do sistema, que só podem ser baixados
+	// quando o host tem o mesmo sistema e arquitetura do alvo
+	SkipPackages bool
+}
+
+// Create baixa todos os itens, gera o manifesto e grava o bundle em opts.Output
+func Create(opts CreateOptions) (*Manifest, error) {
+	if err := checkHostForPackages(opts); err != nil {
+		return nil, err
+	}
+
+	stageDir, err := os.MkdirTemp("", "setup-devops-bundle-")
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar diretório temporário: %w", err)
+	}
+	defer os.RemoveAll(stageDir)
+
+	manifest := &Manifest{
+		Version:   manifestVersion,
+		CreatedAt: time.Now().UTC(),
+		OS:        string(opts.OS),
+		Arch:      opts.Arch,
+		Tools:     opts.Tools,
+	}
+
+	for i, item := range opts.Items {
+		utils.ShowProgress(i+1, len(opts.Items), fmt.Sprintf("Baixando artefatos de %s", item.Tool))
+
+		var paths []string
+		switch item.Kind {
+		case KindRelease, KindKey:
+			path, err := stageURL(stageDir, item)
+			if err != nil {
+				return nil, err
+			}
+			paths = []string{path}
+		case KindPackage:
+			if opts.SkipPackages {
+				color.Yellow("\n⚠️  Pacotes de %s ignorados: %s", item.Tool, strings.Join(item.Packages, ", "))
+				continue
+			}
+			paths, err = stagePackages(stageDir, opts.OS, item)
+			if err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("tipo de artefato desconhecido: %s", item.Kind)
+		}
+
+		for _, path := range paths {
+			sum, size, err := fileSHA256(filepath.Join(stageDir, path))
+			if err != nil {
+				return nil, fmt.Errorf("erro ao calcular checksum de %s: %w", path, err)
+			}
+			manifest.Artifacts = append(manifest.Artifacts, Artifact{
+				Tool:   item.Tool,
+				Kind:   item.Kind,
+				URL:    item.URL,
+				Path:   path,
+				SHA256: sum,
+				Size:   size,
+			})
+		}
+	}
+
+	if err := writeManifest(manifest, filepath.Join(stageDir, ManifestFile)); err != nil {
+		return nil, err
+	}
+
+	if err := writeTar(stageDir, opts.Output); err != nil {
+		return nil, fmt.Errorf("erro ao gravar %s: %w", opts.Output, err)
+	}
+
+	return manifest, nil
+}
+
+// checkHostForPackages garante que os pacotes do sistema possam ser baixados
+// com o gerenciador de pacotes do host
+func checkHostForPackages(opts CreateOptions) error {
+	if opts.SkipPackages {
+		return nil
+	}
+
+	needsPackages := false
+	for _, item := range opts.Items {
+		if item.Kind == KindPackage {
+			needsPackages = true
+			break
+		}
+	}
+	if !needsPackages {
+		return nil
+	}
+
+	hostOS, err := utils.DetectOS()
+	if err != nil {
+		return fmt.Errorf("erro ao detectar sistema operacional: %w", err)
+	}
+
+	if hostOS != opts.OS || runtime.GOARCH != opts.Arch {
+		return fmt.Errorf("pacotes .deb/.rpm só podem ser baixados em um host %s/%s (host atual: %s/%s); use --skip-packages para gerar o bundle sem eles",
+			opts.OS, opts.Arch, hostOS, runtime.GOARCH)
+	}
+
+	return nil
+}
+
+// stageURL baixa um item por URL para files/<tool>/ e retorna o caminho relativo
+func stageURL(stageDir string, item Item) (string, error) {
+	rel := filepath.Join("files", item.Tool, filepath.Base(item.URL))
+	dest := filepath.Join(stageDir, rel)
+
+	if err := os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+		return "", fmt.Errorf("erro ao criar diretório: %w", err)
+	}
+
+	if err := download.Fetch(item.Tool, item.URL, dest); err != nil {
+		return "", err
+	}
+	return rel, nil
+}
+
+// stagePackages baixa os pacotes (com dependências) para packages/<tool>/
+// e retorna os caminhos relativos dos arquivos baixados
+func stagePackages(stageDir string, osType utils.OSType, item Item) ([]string, error) {
+	rel := filepath.Join("packages", item.Tool)
+	dir := filepath.Join(stageDir, rel)
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("erro ao criar diretório: %w", err)
+	}
+
+	if err := downloadPackages(osType, item.Packages, dir); err != nil {
+		return nil, fmt.Errorf("erro ao baixar pacotes de %s: %w", item.Tool, err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			paths = append(paths, filepath.Join(rel, entry.Name()))
+		}
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// downloadPackages baixa os pacotes e todas as suas dependências para dir
+// usando o gerenciador de pacotes do host
+func downloadPackages(osType utils.OSType, pkgs []string, dir string) error {
+	pm, err := pkgmgr.New(pkgmgr.NameFor(&utils.OSInfo{Type: osType}))
+	if err != nil {
+		return err
+	}
+
+	downloader, ok := pm.(pkgmgr.Downloader)
+	if !ok {
+		return fmt.Errorf("download de pacotes não suportado com %s", pm.Name())
+	}
+	return downloader.Download(dir, pkgs...)
+}
+
+// writeTar grava o conteúdo de dir em um arquivo .tar, com o manifesto primeiro
+func writeTar(dir, output string) error {
+	f, err := os.Create(output)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	tw := tar.NewWriter(f)
+
+	var files []string
+	err = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.Mode().IsRegular() {
+			rel, err := filepath.Rel(dir, path)
+			if err != nil {
+				return err
+			}
+			if rel != ManifestFile {
+				files = append(files, rel)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	sort.Strings(files)
+
+	for _, rel := range append([]string{ManifestFile}, files...) {
+		if err := addTarFile(tw, dir, rel); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+// addTarFile adiciona um arquivo de dir ao tar com o caminho relativo rel
+func addTarFile(tw *tar.Writer, dir, rel string) error {
+	path := filepath.Join(dir, rel)
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	header, err := tar.FileInfoHeader(info, "")
+	if err != nil {
+		return err
+	}
+	header.Name = filepath.ToSlash(rel)
+
+	if err := tw.WriteHeader(header); err != nil {
+		return err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = io.Copy(tw, f)
+	return err
+}
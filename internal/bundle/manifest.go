@@ -0,0 +1,102 @@
+// Package bundle cria e abre bundles offline: um arquivo .tar com todos os
+// artefatos (binários, arquivos compactados, pacotes .deb/.rpm e chaves GPG)
+// necessários para instalar as ferramentas sem acesso à rede, acompanhado de
+// um manifesto com o checksum SHA256 de cada arquivo.
+package bundle
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// ManifestFile é o nome do manifesto dentro do bundle
+const ManifestFile = "manifest.json"
+
+// manifestVersion é a versão do formato do manifesto
+const manifestVersion = 1
+
+// Tipos de artefato
+const (
+	KindRelease = "release" // binário ou arquivo compactado baixado por URL
+	KindKey     = "key"     // chave GPG de repositório
+	KindPackage = "package" // pacote .deb/.rpm
+)
+
+// Item descreve algo que precisa ser incluído no bundle para uma ferramenta
+type Item struct {
+	Tool string
+	Kind string
+	// URL de origem (KindRelease e KindKey)
+	URL string
+	// Packages lista os nomes dos pacotes do sistema (KindPackage)
+	Packages []string
+}
+
+// Artifact descreve um arquivo incluído no bundle
+type Artifact struct {
+	Tool   string `json:"tool"`
+	Kind   string `json:"kind"`
+	URL    string `json:"url,omitempty"`
+	Path   string `json:"path"`
+	SHA256 string `json:"sha256"`
+	Size   int64  `json:"size"`
+}
+
+// Manifest descreve o conteúdo de um bundle
+type Manifest struct {
+	Version   int        `json:"version"`
+	CreatedAt time.Time  `json:"createdAt"`
+	OS        string     `json:"os"`
+	Arch      string     `json:"arch"`
+	Tools     []string   `json:"tools"`
+	Artifacts []Artifact `json:"artifacts"`
+}
+
+// readManifest lê e valida o manifesto em path
+func readManifest(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler manifesto: %w", err)
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("manifesto inválido: %w", err)
+	}
+
+	if manifest.Version != manifestVersion {
+		return nil, fmt.Errorf("versão de manifesto não suportada: %d", manifest.Version)
+	}
+
+	return &manifest, nil
+}
+
+// writeManifest grava o manifesto em path
+func writeManifest(manifest *Manifest, path string) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("erro ao gerar manifesto: %w", err)
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// fileSHA256 calcula o checksum SHA256 e o tamanho de um arquivo
+func fileSHA256(path string) (string, int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", 0, err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	size, err := io.Copy(h, f)
+	if err != nil {
+		return "", 0, err
+	}
+	return hex.EncodeToString(h.Sum(nil)), size, nil
+}
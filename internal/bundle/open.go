@@ -0,0 +1,150 @@
+package bundle
+
+import (
+	"archive/tar"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/matheusflausino/setup-devops-cli/internal/download"
+)
+
+// Bundle é um bundle offline extraído e verificado
+type Bundle struct {
+	Dir      string
+	Manifest *Manifest
+}
+
+// active é o bundle usado pelas instalações da sessão atual
+var active *Bundle
+
+// Active retorna o bundle ativo, ou nil quando a instalação é online
+func Active() *Bundle {
+	return active
+}
+
+// Activate torna o bundle a fonte de todos os downloads da sessão
+func Activate(b *Bundle) {
+	active = b
+	download.SetOffline(b.Lookup)
+}
+
+// Open extrai o bundle em um diretório temporário e verifica o checksum de
+// todos os artefatos listados no manifesto
+func Open(path string) (*Bundle, error) {
+	dir, err := os.MkdirTemp("", "setup-devops-bundle-")
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar diretório temporário: %w", err)
+	}
+
+	if err := extractTar(path, dir); err != nil {
+		_ = os.RemoveAll(dir)
+		return nil, fmt.Errorf("erro ao extrair %s: %w", path, err)
+	}
+
+	manifest, err := readManifest(filepath.Join(dir, ManifestFile))
+	if err != nil {
+		_ = os.RemoveAll(dir)
+		return nil, err
+	}
+
+	b := &Bundle{Dir: dir, Manifest: manifest}
+	if err := b.Verify(); err != nil {
+		_ = b.Close()
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// Verify confere tamanho e checksum SHA256 de todos os artefatos
+func (b *Bundle) Verify() error {
+	for _, artifact := range b.Manifest.Artifacts {
+		sum, size, err := fileSHA256(filepath.Join(b.Dir, artifact.Path))
+		if err != nil {
+			return fmt.Errorf("artefato ausente no bundle: %s", artifact.Path)
+		}
+		if size != artifact.Size || sum != artifact.SHA256 {
+			return fmt.Errorf("checksum inválido para %s: esperado %s, obtido %s", artifact.Path, artifact.SHA256, sum)
+		}
+	}
+	return nil
+}
+
+// Lookup retorna o arquivo local correspondente a uma URL de download
+func (b *Bundle) Lookup(rawURL string) (string, bool) {
+	for _, artifact := range b.Manifest.Artifacts {
+		if artifact.URL == rawURL && artifact.Kind != KindPackage {
+			return filepath.Join(b.Dir, artifact.Path), true
+		}
+	}
+	return "", false
+}
+
+// Files retorna os caminhos locais dos artefatos de uma ferramenta com o tipo informado
+func (b *Bundle) Files(tool, kind string) []string {
+	var files []string
+	for _, artifact := range b.Manifest.Artifacts {
+		if artifact.Tool == tool && artifact.Kind == kind {
+			files = append(files, filepath.Join(b.Dir, artifact.Path))
+		}
+	}
+	return files
+}
+
+// Close remove os arquivos extraídos
+func (b *Bundle) Close() error {
+	if active == b {
+		active = nil
+		download.SetOffline(nil)
+	}
+	return os.RemoveAll(b.Dir)
+}
+
+// extractTar extrai os arquivos regulares de um .tar para dir
+func extractTar(path, dir string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	tr := tar.NewReader(f)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		dest := filepath.Join(dir, filepath.FromSlash(header.Name))
+		if !strings.HasPrefix(dest, filepath.Clean(dir)+string(os.PathSeparator)) {
+			return fmt.Errorf("caminho inválido no bundle: %s", header.Name)
+		}
+
+		if err := os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+			return err
+		}
+
+		out, err := os.OpenFile(dest, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(out, tr); err != nil { // #nosec G110 -- o tamanho é verificado pelo manifesto
+			_ = out.Close()
+			return err
+		}
+		if err := out.Close(); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,94 @@
+// Package cluster cria e remove clusters Kubernetes locais com kind,
+// minikube ou k3d, sempre rodando os nós como containers Docker.
+package cluster
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/matheusflausino/setup-devops-cli/internal/utils"
+)
+
+// Provider descreve como uma ferramenta cria e remove clusters locais
+type Provider struct {
+	// Name é o nome da ferramenta (e do comando)
+	Name string
+	// Create e Delete retornam os argumentos para criar e remover o cluster
+	Create func(name string) []string
+	Delete func(name string) []string
+	// Context retorna o contexto que a ferramenta grava no kubeconfig
+	Context func(name string) string
+}
+
+// providers são as ferramentas de cluster local suportadas
+var providers = map[string]Provider{
+	"kind": {
+		Name:    "kind",
+		Create:  func(name string) []string { return []string{"create", "cluster", "--name", name} },
+		Delete:  func(name string) []string { return []string{"delete", "cluster", "--name", name} },
+		Context: func(name string) string { return "kind-" + name },
+	},
+	"minikube": {
+		Name:    "minikube",
+		Create:  func(name string) []string { return []string{"start", "--driver=docker", "--profile", name} },
+		Delete:  func(name string) []string { return []string{"delete", "--profile", name} },
+		Context: func(name string) string { return name },
+	},
+	"k3d": {
+		Name: "k3d",
+		Create: func(name string) []string {
+			return []string{"cluster", "create", name, "--kubeconfig-update-default", "--kubeconfig-switch-context"}
+		},
+		Delete:  func(name string) []string { return []string{"cluster", "delete", name} },
+		Context: func(name string) string { return "k3d-" + name },
+	},
+}
+
+// Names retorna as ferramentas de cluster local suportadas
+func Names() []string {
+	names := make([]string, 0, len(providers))
+	for name := range providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Get retorna a ferramenta de cluster local pelo nome
+func Get(name string) (Provider, error) {
+	provider, ok := providers[name]
+	if !ok {
+		return Provider{}, fmt.Errorf("ferramenta de cluster não suportada: %q (use %s)", name, strings.Join(Names(), ", "))
+	}
+	return provider, nil
+}
+
+// Up cria o cluster e torna o seu contexto o atual no kubeconfig
+func (p Provider) Up(name string) error {
+	if output, err := utils.RunCommandCapture(p.Name, p.Create(name)...); err != nil {
+		return fmt.Errorf("erro ao criar cluster %s com %s: %s", name, p.Name, utils.LastLines(output))
+	}
+
+	if output, err := utils.RunCommandCapture("kubectl", "config", "use-context", p.Context(name)); err != nil {
+		return fmt.Errorf("erro ao selecionar o contexto %s: %s", p.Context(name), utils.LastLines(output))
+	}
+	return nil
+}
+
+// Down remove o cluster (e o seu contexto do kubeconfig)
+func (p Provider) Down(name string) error {
+	if output, err := utils.RunCommandCapture(p.Name, p.Delete(name)...); err != nil {
+		return fmt.Errorf("erro ao remover cluster %s com %s: %s", name, p.Name, utils.LastLines(output))
+	}
+	return nil
+}
+
+// Verify lista os nós do cluster com kubectl e retorna a saída
+func (p Provider) Verify(name string) (string, error) {
+	output, err := utils.RunCommandCapture("kubectl", "--context", p.Context(name), "get", "nodes")
+	if err != nil {
+		return "", fmt.Errorf("o cluster não respondeu a 'kubectl get nodes': %s", utils.LastLines(output))
+	}
+	return output, nil
+}
@@ -0,0 +1,355 @@
+// Package config centraliza a leitura das configurações da CLI.
+//
+// Os valores podem vir de flags, variáveis de ambiente ou do arquivo
+// de configuração (~/.setup-devops.yaml), nessa ordem de precedência.
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultPrefix é o diretório base padrão para instalação de binários
+const DefaultPrefix = "/usr/local"
+
+// Prefix retorna o diretório base usado para instalação de binários
+func Prefix() string {
+	prefix := viper.GetString("prefix")
+	if prefix == "" {
+		return DefaultPrefix
+	}
+	return filepath.Clean(ExpandHome(prefix))
+}
+
+// ExpandHome expande o prefixo "~" para o diretório home do usuário
+func ExpandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~"))
+}
+
+// MirrorConfig descreve os mirrors configurados na seção "mirrors"
+type MirrorConfig struct {
+	// Global lista mirrors genéricos usados para qualquer origem, no formato <mirror>/<host>/<caminho>
+	Global []string
+	// Tools lista mirrors por ferramenta, que substituem esquema e host da URL original
+	Tools map[string][]string
+	// Upstream indica se a URL original deve ser tentada depois dos mirrors
+	Upstream bool
+}
+
+// Mirrors retorna a configuração de mirrors
+func Mirrors() MirrorConfig {
+	upstream := true
+	if viper.IsSet("mirrors.upstream") {
+		upstream = viper.GetBool("mirrors.upstream")
+	}
+
+	return MirrorConfig{
+		Global:   viper.GetStringSlice("mirrors.global"),
+		Tools:    viper.GetStringMapStringSlice("mirrors.tools"),
+		Upstream: upstream,
+	}
+}
+
+// configFiles são os arquivos de configuração lidos, em ordem de
+// precedência crescente, para as seções lidas sem o viper
+var configFiles []string
+
+// LoadProfile mescla um arquivo de perfil (ex: team.yaml) às configurações atuais
+func LoadProfile(path string) error {
+	if len(configFiles) == 0 && viper.ConfigFileUsed() != "" {
+		configFiles = append(configFiles, viper.ConfigFileUsed())
+	}
+
+	path = ExpandHome(path)
+	viper.SetConfigFile(path)
+	if err := viper.MergeInConfig(); err != nil {
+		return err
+	}
+	configFiles = append(configFiles, path)
+	return nil
+}
+
+// loadedFiles retorna os arquivos de configuração lidos
+func loadedFiles() []string {
+	if len(configFiles) == 0 && viper.ConfigFileUsed() != "" {
+		return []string{viper.ConfigFileUsed()}
+	}
+	return configFiles
+}
+
+// ProfileTools retorna as ferramentas e grupos selecionados no perfil
+func ProfileTools() (tools, groups []string) {
+	return viper.GetStringSlice("tools"), viper.GetStringSlice("groups")
+}
+
+// DefaultCacheMaxSize é o tamanho máximo padrão do cache de downloads (2 GB)
+const DefaultCacheMaxSize int64 = 2 << 30
+
+// CacheDir retorna o diretório do cache de downloads
+func CacheDir() string {
+	if dir := viper.GetString("cache.dir"); dir != "" {
+		return filepath.Clean(ExpandHome(dir))
+	}
+	if dir := os.Getenv("XDG_CACHE_HOME"); dir != "" {
+		return filepath.Join(dir, "setup-devops")
+	}
+	return ExpandHome("~/.cache/setup-devops")
+}
+
+// CacheEnabled indica se os downloads devem passar pelo cache
+func CacheEnabled() bool {
+	if viper.IsSet("cache.enabled") {
+		return viper.GetBool("cache.enabled")
+	}
+	return true
+}
+
+// CacheMaxSize retorna o tamanho máximo do cache em bytes, ou um erro
+// quando cache.maxSize não é um tamanho válido
+func CacheMaxSize() (int64, error) {
+	if !viper.IsSet("cache.maxSize") {
+		return DefaultCacheMaxSize, nil
+	}
+
+	size, err := ParseSize(viper.GetString("cache.maxSize"))
+	if err != nil {
+		return 0, fmt.Errorf("cache.maxSize inválido: %w", err)
+	}
+	return size, nil
+}
+
+// ParseSize converte tamanhos como "500MB", "500M", "2GB", "2G" ou
+// "1048576" em bytes
+func ParseSize(raw string) (int64, error) {
+	value := strings.ToUpper(strings.TrimSpace(raw))
+
+	units := []struct {
+		suffix string
+		factor int64
+	}{
+		{"TB", 1 << 40},
+		{"GB", 1 << 30},
+		{"MB", 1 << 20},
+		{"KB", 1 << 10},
+		{"T", 1 << 40},
+		{"G", 1 << 30},
+		{"M", 1 << 20},
+		{"K", 1 << 10},
+		{"B", 1},
+	}
+
+	factor := int64(1)
+	for _, unit := range units {
+		if strings.HasSuffix(value, unit.suffix) {
+			factor = unit.factor
+			value = strings.TrimSpace(strings.TrimSuffix(value, unit.suffix))
+			break
+		}
+	}
+
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil || number < 0 {
+		return 0, fmt.Errorf("tamanho inválido: %q", raw)
+	}
+	return int64(number * float64(factor)), nil
+}
+
+// Force indica se verificações de compatibilidade (ex: versão mínima do sistema) devem ser ignoradas
+func Force() bool {
+	return viper.GetBool("force")
+}
+
+// DefaultLockTimeout é o tempo máximo padrão de espera pelo lock do gerenciador de pacotes
+const DefaultLockTimeout = 5 * time.Minute
+
+// DefaultPackageRetries é o número padrão de novas tentativas após falhas transitórias
+const DefaultPackageRetries = 3
+
+// LockTimeout retorna quanto tempo aguardar o lock do gerenciador de pacotes
+// (dpkg, rpm...) quando outro processo, como o unattended-upgrades, o segura
+func LockTimeout() time.Duration {
+	if !viper.IsSet("packages.lockTimeout") {
+		return DefaultLockTimeout
+	}
+	return viper.GetDuration("packages.lockTimeout")
+}
+
+// PackageRetries retorna quantas vezes repetir um comando do gerenciador de
+// pacotes após uma falha transitória (ex: mirror em sincronização)
+func PackageRetries() int {
+	if !viper.IsSet("packages.retries") {
+		return DefaultPackageRetries
+	}
+	return viper.GetInt("packages.retries")
+}
+
+// Container indica se o modo container foi forçado (--container), para
+// ambientes que a detecção automática não reconhece
+func Container() bool {
+	return viper.GetBool("container")
+}
+
+// Escalation retorna a estratégia de elevação de privilégios configurada
+// (auto, sudo, doas ou none)
+func Escalation() string {
+	escalation := viper.GetString("escalation")
+	if escalation == "" {
+		return "auto"
+	}
+	return escalation
+}
+
+// DockerDaemon retorna as opções do daemon do Docker (seção docker.daemon)
+// a mesclar em /etc/docker/daemon.json. A seção é lida diretamente dos
+// arquivos, pois o viper converte as chaves para minúsculas e o daemon.json
+// tem chaves em camelCase (ex: builder.gc.defaultKeepStorage).
+func DockerDaemon() map[string]interface{} {
+	settings := map[string]interface{}{}
+	for _, path := range loadedFiles() {
+		var file struct {
+			Docker struct {
+				Daemon map[string]interface{} `yaml:"daemon"`
+			} `yaml:"docker"`
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil || yaml.Unmarshal(data, &file) != nil {
+			continue
+		}
+		for key, value := range file.Docker.Daemon {
+			settings[key] = value
+		}
+	}
+	return settings
+}
+
+// DockerProvider retorna o provedor configurado para o Docker (docker-ce,
+// podman, docker-desktop, colima), ou "auto" para detectar
+func DockerProvider() string {
+	provider := viper.GetString("docker.provider")
+	if provider == "" {
+		return "auto"
+	}
+	return provider
+}
+
+// GcloudComponents retorna os componentes do Google Cloud CLI a instalar
+// (seção gcloud.components, ex: gke-gcloud-auth-plugin)
+func GcloudComponents() []string {
+	return viper.GetStringSlice("gcloud.components")
+}
+
+// DefaultClusterName é o nome padrão do cluster Kubernetes local
+const DefaultClusterName = "setup-devops"
+
+// ClusterTool retorna a ferramenta de cluster local configurada (kind,
+// minikube, k3d), ou "" para escolher automaticamente
+func ClusterTool() string {
+	return viper.GetString("cluster.tool")
+}
+
+// ClusterName retorna o nome do cluster Kubernetes local
+func ClusterName() string {
+	if name := viper.GetString("cluster.name"); name != "" {
+		return name
+	}
+	return DefaultClusterName
+}
+
+// ToolVersion retorna a versão fixada para a ferramenta na seção versions
+// (ex: versions.terragrunt: v0.67.16), ou "" para usar a versão padrão
+func ToolVersion(tool string) string {
+	return viper.GetString("versions." + tool)
+}
+
+// HelmPlugin descreve um plugin do Helm da seção helm.plugins
+type HelmPlugin struct {
+	Name string
+	// URL é o repositório do plugin (opcional para os plugins conhecidos, ex: diff)
+	URL string
+	// Version fixa a versão do plugin (vazio para a mais recente)
+	Version string
+}
+
+// HelmPlugins retorna os plugins do Helm configurados em helm.plugins. Cada
+// item pode ser só o nome (ex: diff) ou um mapa com name, url e version.
+func HelmPlugins() []HelmPlugin {
+	items, _ := viper.Get("helm.plugins").([]interface{})
+
+	plugins := make([]HelmPlugin, 0, len(items))
+	for _, item := range items {
+		switch value := item.(type) {
+		case string:
+			plugins = append(plugins, HelmPlugin{Name: value})
+		case map[string]interface{}:
+			if stringValue(value["name"]) == "" {
+				continue
+			}
+			plugins = append(plugins, HelmPlugin{
+				Name:    stringValue(value["name"]),
+				URL:     stringValue(value["url"]),
+				Version: stringValue(value["version"]),
+			})
+		}
+	}
+	return plugins
+}
+
+// KrewPlugins retorna os plugins do kubectl a instalar com o krew (seção
+// kubectl.krewPlugins, ex: ctx, ns, neat)
+func KrewPlugins() []string {
+	return viper.GetStringSlice("kubectl.krewPlugins")
+}
+
+// stringValue converte um valor opcional do arquivo de configuração
+func stringValue(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprint(value)
+}
+
+// DefaultTflintConfig é o arquivo de configuração padrão do tflint
+const DefaultTflintConfig = ".tflint.hcl"
+
+// TflintConfig retorna o arquivo de configuração do tflint cujos plugins
+// são instalados com "tflint --init"
+func TflintConfig() string {
+	if path := viper.GetString("tflint.config"); path != "" {
+		return path
+	}
+	return DefaultTflintConfig
+}
+
+// ShimsDir retorna o diretório dos shims que executam a versão fixada pelo
+// projeto (shims.dir, padrão ~/.local/share/setup-devops/shims)
+func ShimsDir() string {
+	if dir := viper.GetString("shims.dir"); dir != "" {
+		return filepath.Clean(ExpandHome(dir))
+	}
+	if dir := os.Getenv("XDG_DATA_HOME"); dir != "" {
+		return filepath.Join(dir, "setup-devops", "shims")
+	}
+	return ExpandHome("~/.local/share/setup-devops/shims")
+}
+
+// ShimsAutoInstall indica se os shims podem instalar a versão fixada pelo
+// projeto no primeiro uso (shims.autoInstall ou SETUP_DEVOPS_AUTO_INSTALL)
+func ShimsAutoInstall() bool {
+	return viper.GetBool("shims.autoInstall")
+}
@@ -0,0 +1,393 @@
+package download
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/matheusflausino/setup-devops-cli/internal/config"
+)
+
+// Cache de downloads endereçado por conteúdo:
+//
+//	<cache>/blobs/sha256/<checksum>  - conteúdo de cada arquivo baixado
+//	<cache>/index.json               - URL original (+ checksum esperado) -> checksum, tamanho e último uso
+//
+// A chave é a URL original (antes da reescrita por mirrors) e, quando o
+// instalador conhece o checksum esperado, também esse checksum: o mesmo
+// artefato baixado de mirrors diferentes é reaproveitado, um artefato
+// republicado na mesma URL não é servido no lugar do novo, e arquivos
+// idênticos em URLs diferentes ocupam espaço uma única vez. URLs sem versão
+// nem checksum (ex: o instalador "latest" do AWS CLI) são indexadas pela URL
+// e revalidadas a cada uso com uma requisição condicional (ETag ou
+// Last-Modified), sendo baixadas novamente apenas quando mudam.
+
+// CacheEntry descreve um arquivo no cache
+type CacheEntry struct {
+	URL    string `json:"url"`
+	SHA256 string `json:"sha256"`
+	Size   int64  `json:"size"`
+	// Verified indica que o checksum foi conferido com o valor esperado
+	// informado pelo instalador e faz parte da chave
+	Verified bool `json:"verified,omitempty"`
+	// ETag e LastModified são os validadores informados pelo servidor, usados
+	// para revalidar as URLs sem versão
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"lastModified,omitempty"`
+	Created      time.Time `json:"created"`
+	LastUsed     time.Time `json:"lastUsed"`
+}
+
+// revalidation é o resultado de um download condicional
+type revalidation struct {
+	// NotModified indica que o servidor confirmou o conteúdo em cache (304)
+	NotModified  bool
+	ETag         string
+	LastModified string
+}
+
+// cacheIndex é o conteúdo de index.json
+type cacheIndex struct {
+	Entries map[string]*CacheEntry `json:"entries"`
+}
+
+// key retorna a chave da entrada no índice
+func (e *CacheEntry) key() string {
+	if e.Verified {
+		return cacheKey(e.URL, e.SHA256)
+	}
+	return cacheKey(e.URL, "")
+}
+
+// cacheKey monta a chave do índice a partir da URL e do checksum esperado
+func cacheKey(rawURL, sum string) string {
+	if sum == "" {
+		return rawURL
+	}
+	return rawURL + "#sha256=" + strings.ToLower(sum)
+}
+
+// blobPath retorna o caminho do conteúdo com o checksum informado
+func blobPath(sum string) string {
+	return filepath.Join(config.CacheDir(), "blobs", "sha256", sum)
+}
+
+// indexPath retorna o caminho do índice do cache
+func indexPath() string {
+	return filepath.Join(config.CacheDir(), "index.json")
+}
+
+// loadIndex lê o índice do cache (vazio se ainda não existir)
+func loadIndex() (*cacheIndex, error) {
+	index := &cacheIndex{Entries: make(map[string]*CacheEntry)}
+
+	data, err := os.ReadFile(indexPath())
+	if errors.Is(err, os.ErrNotExist) {
+		return index, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler índice do cache: %w", err)
+	}
+
+	if err := json.Unmarshal(data, index); err != nil {
+		return nil, fmt.Errorf("índice do cache corrompido (use 'setup-devops cache clear'): %w", err)
+	}
+	if index.Entries == nil {
+		index.Entries = make(map[string]*CacheEntry)
+	}
+	return index, nil
+}
+
+// save grava o índice de forma atômica
+func (idx *cacheIndex) save() error {
+	data, err := json.MarshalIndent(idx, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp := indexPath() + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("erro ao gravar índice do cache: %w", err)
+	}
+	return os.Rename(tmp, indexPath())
+}
+
+// fetchCached atende o download pelo cache quando possível; caso contrário
+// usa download para baixar em um arquivo temporário e armazena o resultado.
+// Com expected, o conteúdo precisa ter esse checksum SHA256, tanto no cache
+// quanto no novo download.
+func fetchCached(rawURL, expected, dest string, download func(tmp string) error) error {
+	if err := os.MkdirAll(filepath.Join(config.CacheDir(), "blobs", "sha256"), 0o755); err != nil {
+		if err := download(dest); err != nil {
+			return err
+		}
+		return verifyExpected(dest, expected)
+	}
+
+	index, err := loadIndex()
+	if err != nil {
+		return err
+	}
+
+	key := cacheKey(rawURL, expected)
+	if entry, ok := index.Entries[key]; ok {
+		if sum, _, err := hashFile(blobPath(entry.SHA256)); err == nil && sum == entry.SHA256 {
+			entry.LastUsed = time.Now().UTC()
+			if err := index.save(); err != nil {
+				return err
+			}
+			return copyFile(blobPath(entry.SHA256), dest)
+		}
+		// Conteúdo ausente ou corrompido: baixar novamente
+		delete(index.Entries, key)
+	}
+
+	tmp, err := os.CreateTemp(config.CacheDir(), "download-*")
+	if err != nil {
+		return fmt.Errorf("erro ao criar arquivo temporário no cache: %w", err)
+	}
+	_ = tmp.Close()
+	defer os.Remove(tmp.Name())
+
+	if err := download(tmp.Name()); err != nil {
+		return err
+	}
+
+	sum, err := index.store(key, tmp.Name(), &CacheEntry{URL: rawURL, Verified: expected != ""}, expected)
+	if err != nil {
+		return err
+	}
+	return copyFile(blobPath(sum), dest)
+}
+
+// fetchRevalidated atende URLs sem versão pelo cache: download recebe a
+// entrada em cache (ou nil) e faz uma requisição condicional com os
+// validadores dela, de forma que o arquivo só é baixado novamente quando o
+// servidor informa que ele mudou
+func fetchRevalidated(rawURL, dest string, download func(tmp string, cached *CacheEntry) (*revalidation, error)) error {
+	if err := os.MkdirAll(filepath.Join(config.CacheDir(), "blobs", "sha256"), 0o755); err != nil {
+		_, err := download(dest, nil)
+		return err
+	}
+
+	index, err := loadIndex()
+	if err != nil {
+		return err
+	}
+
+	key := cacheKey(rawURL, "")
+	cached := index.Entries[key]
+	if cached != nil {
+		// Sem validadores ou sem o conteúdo não há como revalidar
+		if sum, _, err := hashFile(blobPath(cached.SHA256)); err != nil || sum != cached.SHA256 || (cached.ETag == "" && cached.LastModified == "") {
+			delete(index.Entries, key)
+			cached = nil
+		}
+	}
+
+	tmp, err := os.CreateTemp(config.CacheDir(), "download-*")
+	if err != nil {
+		return fmt.Errorf("erro ao criar arquivo temporário no cache: %w", err)
+	}
+	_ = tmp.Close()
+	defer os.Remove(tmp.Name())
+
+	result, err := download(tmp.Name(), cached)
+	if err != nil {
+		return err
+	}
+
+	if result.NotModified && cached != nil {
+		cached.LastUsed = time.Now().UTC()
+		if err := index.save(); err != nil {
+			return err
+		}
+		return copyFile(blobPath(cached.SHA256), dest)
+	}
+
+	entry := &CacheEntry{URL: rawURL, ETag: result.ETag, LastModified: result.LastModified}
+	sum, err := index.store(key, tmp.Name(), entry, "")
+	if err != nil {
+		return err
+	}
+	return copyFile(blobPath(sum), dest)
+}
+
+// store move o arquivo baixado em tmp para o cache, conferindo o checksum
+// esperado quando informado, e registra entry no índice com a chave key.
+// Retorna o checksum do conteúdo.
+func (idx *cacheIndex) store(key, tmp string, entry *CacheEntry, expected string) (string, error) {
+	maxSize, err := config.CacheMaxSize()
+	if err != nil {
+		return "", err
+	}
+
+	sum, size, err := hashFile(tmp)
+	if err != nil {
+		return "", fmt.Errorf("erro ao calcular checksum: %w", err)
+	}
+	if expected != "" && !strings.EqualFold(sum, expected) {
+		return "", fmt.Errorf("checksum inválido para %s: esperado %s, obtido %s", filepath.Base(entry.URL), expected, sum)
+	}
+
+	if err := os.Rename(tmp, blobPath(sum)); err != nil {
+		return "", fmt.Errorf("erro ao armazenar no cache: %w", err)
+	}
+
+	now := time.Now().UTC()
+	entry.SHA256, entry.Size, entry.Created, entry.LastUsed = sum, size, now, now
+	idx.Entries[key] = entry
+
+	if _, err := idx.prune(maxSize, key); err != nil {
+		return "", err
+	}
+	if err := idx.save(); err != nil {
+		return "", err
+	}
+	return sum, nil
+}
+
+// prune remove entradas menos usadas recentemente até que o cache caiba em
+// maxSize, além de conteúdos sem entrada no índice. A entrada keep nunca é
+// removida. Retorna a quantidade de bytes liberados.
+func (idx *cacheIndex) prune(maxSize int64, keep string) (int64, error) {
+	entries := make([]*CacheEntry, 0, len(idx.Entries))
+	for _, entry := range idx.Entries {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].LastUsed.Before(entries[j].LastUsed)
+	})
+
+	total := idx.size()
+	for _, entry := range entries {
+		if total <= maxSize {
+			break
+		}
+		if entry.key() == keep {
+			continue
+		}
+		delete(idx.Entries, entry.key())
+		if !idx.references(entry.SHA256) {
+			total -= entry.Size
+		}
+	}
+
+	return idx.removeOrphans()
+}
+
+// size retorna o tamanho ocupado pelos conteúdos referenciados no índice
+func (idx *cacheIndex) size() int64 {
+	var total int64
+	seen := make(map[string]bool)
+	for _, entry := range idx.Entries {
+		if !seen[entry.SHA256] {
+			seen[entry.SHA256] = true
+			total += entry.Size
+		}
+	}
+	return total
+}
+
+// references verifica se algum item do índice usa o conteúdo informado
+func (idx *cacheIndex) references(sum string) bool {
+	for _, entry := range idx.Entries {
+		if entry.SHA256 == sum {
+			return true
+		}
+	}
+	return false
+}
+
+// removeOrphans apaga conteúdos que não são referenciados pelo índice
+func (idx *cacheIndex) removeOrphans() (int64, error) {
+	dir := filepath.Join(config.CacheDir(), "blobs", "sha256")
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	var freed int64
+	for _, file := range files {
+		if idx.references(file.Name()) {
+			continue
+		}
+		if info, err := file.Info(); err == nil {
+			freed += info.Size()
+		}
+		if err := os.Remove(filepath.Join(dir, file.Name())); err != nil {
+			return freed, fmt.Errorf("erro ao remover %s do cache: %w", file.Name(), err)
+		}
+	}
+	return freed, nil
+}
+
+// CacheList retorna as entradas do cache ordenadas por último uso (mais recente primeiro)
+func CacheList() ([]CacheEntry, error) {
+	index, err := loadIndex()
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]CacheEntry, 0, len(index.Entries))
+	for _, entry := range index.Entries {
+		entries = append(entries, *entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].LastUsed.After(entries[j].LastUsed)
+	})
+	return entries, nil
+}
+
+// CachePrune reduz o cache ao tamanho máximo informado, removendo primeiro
+// as entradas usadas há mais tempo. Retorna a quantidade de bytes liberados.
+func CachePrune(maxSize int64) (int64, error) {
+	index, err := loadIndex()
+	if err != nil {
+		return 0, err
+	}
+
+	freed, err := index.prune(maxSize, "")
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err := os.Stat(config.CacheDir()); err == nil {
+		if err := index.save(); err != nil {
+			return 0, err
+		}
+	}
+	return freed, nil
+}
+
+// CacheClear remove todo o conteúdo do cache
+func CacheClear() error {
+	return os.RemoveAll(config.CacheDir())
+}
+
+// hashFile calcula o checksum SHA256 e o tamanho de um arquivo
+func hashFile(path string) (string, int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", 0, err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	size, err := io.Copy(h, f)
+	if err != nil {
+		return "", 0, err
+	}
+	return hex.EncodeToString(h.Sum(nil)), size, nil
+}
@@ -0,0 +1,287 @@
+// Package download concentra os downloads feitos pelos instaladores,
+// aplicando os mirrors configurados e tentando as alternativas em ordem.
+package download
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/matheusflausino/setup-devops-cli/internal/config"
+	"github.com/matheusflausino/setup-devops-cli/internal/utils"
+)
+
+// LocalSource resolve uma URL para um arquivo local já verificado
+type LocalSource func(rawURL string) (string, bool)
+
+// offlineSource, quando definido, atende todos os downloads sem acesso à rede
+var offlineSource LocalSource
+
+// SetOffline faz com que todos os downloads sejam atendidos pela fonte local
+// informada (por exemplo, um bundle offline). Passe nil para voltar ao modo online.
+func SetOffline(source LocalSource) {
+	offlineSource = source
+}
+
+// Offline indica se os downloads estão sendo atendidos por uma fonte local
+func Offline() bool {
+	return offlineSource != nil
+}
+
+// Origin retorna o esquema e host de uma URL (ex: https://dl.k8s.io)
+func Origin(rawURL string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("URL inválida %s: %w", rawURL, err)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return "", fmt.Errorf("URL inválida: %s", rawURL)
+	}
+	return u.Scheme + "://" + u.Host, nil
+}
+
+// Bases retorna, em ordem de preferência, as bases que substituem a origem
+// de rawURL: mirrors da ferramenta, mirrors globais e a própria origem
+func Bases(tool, rawURL string) ([]string, error) {
+	origin, err := Origin(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	host := strings.TrimPrefix(strings.TrimPrefix(origin, "https://"), "http://")
+
+	mirrors := config.Mirrors()
+	var bases []string
+
+	for _, mirror := range mirrors.Tools[tool] {
+		bases = append(bases, strings.TrimRight(mirror, "/"))
+	}
+
+	for _, mirror := range mirrors.Global {
+		bases = append(bases, strings.TrimRight(mirror, "/")+"/"+host)
+	}
+
+	if mirrors.Upstream || len(bases) == 0 {
+		bases = append(bases, origin)
+	}
+
+	return bases, nil
+}
+
+// Rewrite substitui a origem em todas as URLs contidas em text pela base informada
+func Rewrite(text, origin, base string) string {
+	if origin == base {
+		return text
+	}
+	return strings.ReplaceAll(text, origin, base)
+}
+
+// Fetch baixa rawURL para dest, tentando os mirrors configurados em ordem.
+// O conteúdo é reaproveitado do cache local quando a URL, com versão, já foi
+// baixada; URLs sem versão são revalidadas com o servidor a cada uso.
+func Fetch(tool, rawURL, dest string) error {
+	if versioned(rawURL) {
+		return FetchVerified(tool, rawURL, dest, "")
+	}
+
+	if offlineSource != nil || !config.CacheEnabled() {
+		_, err := FetchWithBase(tool, rawURL, dest)
+		return err
+	}
+	return fetchRevalidated(rawURL, dest, func(tmp string, cached *CacheEntry) (*revalidation, error) {
+		return fetchConditional(tool, rawURL, tmp, cached)
+	})
+}
+
+// FetchVerified funciona como Fetch, mas confere o checksum SHA256 esperado,
+// que também faz parte da chave do cache: um artefato republicado na mesma
+// URL nunca é servido pelo cache no lugar do publicado com esse checksum
+func FetchVerified(tool, rawURL, dest, sha256 string) error {
+	if offlineSource != nil || !config.CacheEnabled() {
+		if _, err := FetchWithBase(tool, rawURL, dest); err != nil {
+			return err
+		}
+		return verifyExpected(dest, sha256)
+	}
+
+	return fetchCached(rawURL, sha256, dest, func(tmp string) error {
+		_, err := FetchWithBase(tool, rawURL, tmp)
+		return err
+	})
+}
+
+// versionPattern encontra números de versão (ex: v1.30.0, 2.29) em URLs
+var versionPattern = regexp.MustCompile(`[0-9]+\.[0-9]+`)
+
+// versioned verifica se o caminho da URL contém uma versão, ou seja, se o
+// conteúdo não deve mudar sem que a URL mude
+func versioned(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	return versionPattern.MatchString(u.Path)
+}
+
+// verifyExpected confere o checksum quando há um valor esperado
+func verifyExpected(path, expected string) error {
+	if expected == "" {
+		return nil
+	}
+	return Verify(path, expected)
+}
+
+// Verify confere o checksum SHA256 do arquivo com o valor esperado
+func Verify(path, expected string) error {
+	sum, _, err := hashFile(path)
+	if err != nil {
+		return fmt.Errorf("erro ao calcular checksum de %s: %w", filepath.Base(path), err)
+	}
+	if !strings.EqualFold(sum, expected) {
+		return fmt.Errorf("checksum inválido para %s: esperado %s, obtido %s", filepath.Base(path), expected, sum)
+	}
+	return nil
+}
+
+// FetchWithBase funciona como Fetch, mas retorna a base (mirror ou origem)
+// que respondeu, para que outras URLs da mesma origem possam ser
+// reescritas com Rewrite apontando para o mesmo mirror. Não usa o cache:
+// é usado para chaves e arquivos de repositório, que mudam sem mudar de URL.
+func FetchWithBase(tool, rawURL, dest string) (string, error) {
+	origin, err := Origin(rawURL)
+	if err != nil {
+		return "", err
+	}
+
+	if offlineSource != nil {
+		return origin, fetchOffline(rawURL, dest)
+	}
+
+	bases, err := Bases(tool, rawURL)
+	if err != nil {
+		return "", err
+	}
+
+	var lastErr error
+	for i, base := range bases {
+		candidate := Rewrite(rawURL, origin, base)
+		if err := utils.RunCommand("curl", "-fsSL", candidate, "-o", dest); err != nil {
+			lastErr = err
+			if i < len(bases)-1 {
+				color.Yellow("⚠️  Falha ao baixar de %s, tentando a próxima origem...", candidate)
+			}
+			continue
+		}
+		return base, nil
+	}
+
+	return "", fmt.Errorf("erro ao baixar %s (%d origens tentadas): %w", rawURL, len(bases), lastErr)
+}
+
+// fetchConditional baixa rawURL para dest como FetchWithBase, enviando os
+// validadores da entrada em cache (If-None-Match/If-Modified-Since) para
+// que o servidor responda 304 quando o conteúdo não mudou
+func fetchConditional(tool, rawURL, dest string, cached *CacheEntry) (*revalidation, error) {
+	origin, err := Origin(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	bases, err := Bases(tool, rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	headers := dest + ".headers"
+	defer os.Remove(headers)
+
+	args := []string{"-fsSL", "-D", headers, "-w", "%{http_code}", "-o", dest}
+	if cached != nil {
+		if cached.ETag != "" {
+			args = append(args, "-H", "If-None-Match: "+cached.ETag)
+		}
+		if cached.LastModified != "" {
+			args = append(args, "-H", "If-Modified-Since: "+cached.LastModified)
+		}
+	}
+
+	var lastErr error
+	for i, base := range bases {
+		candidate := Rewrite(rawURL, origin, base)
+		status, err := utils.RunCommandOutput("curl", append(args, candidate)...)
+		if err != nil {
+			lastErr = err
+			if i < len(bases)-1 {
+				color.Yellow("⚠️  Falha ao baixar de %s, tentando a próxima origem...", candidate)
+			}
+			continue
+		}
+
+		result := responseValidators(headers)
+		result.NotModified = strings.TrimSpace(status) == "304"
+		return result, nil
+	}
+
+	return nil, fmt.Errorf("erro ao baixar %s (%d origens tentadas): %w", rawURL, len(bases), lastErr)
+}
+
+// responseValidators lê ETag e Last-Modified da última resposta gravada em
+// headers (após os redirecionamentos)
+func responseValidators(headers string) *revalidation {
+	result := &revalidation{}
+
+	data, err := os.ReadFile(headers)
+	if err != nil {
+		return result
+	}
+
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		name, value, found := strings.Cut(line, ":")
+		switch {
+		case strings.HasPrefix(line, "HTTP/"):
+			// Início de uma nova resposta: descartar os validadores do redirecionamento
+			result = &revalidation{}
+		case !found:
+		case strings.EqualFold(name, "ETag"):
+			result.ETag = strings.TrimSpace(value)
+		case strings.EqualFold(name, "Last-Modified"):
+			result.LastModified = strings.TrimSpace(value)
+		}
+	}
+	return result
+}
+
+// fetchOffline copia para dest o arquivo local correspondente a rawURL
+func fetchOffline(rawURL, dest string) error {
+	path, ok := offlineSource(rawURL)
+	if !ok {
+		return fmt.Errorf("artefato não disponível no modo offline: %s", rawURL)
+	}
+
+	return copyFile(path, dest)
+}
+
+// copyFile copia o conteúdo de src para dest
+func copyFile(src, dest string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("erro ao abrir %s: %w", src, err)
+	}
+	defer in.Close()
+
+	out, err := os.Create(dest)
+	if err != nil {
+		return fmt.Errorf("erro ao criar %s: %w", dest, err)
+	}
+
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return fmt.Errorf("erro ao copiar %s: %w", src, err)
+	}
+	return out.Close()
+}
@@ -0,0 +1,110 @@
+// Package initsys abstrai o sistema de init (systemd, OpenRC ou nenhum) usado
+// para iniciar e habilitar serviços como o daemon do Docker.
+package initsys
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/matheusflausino/setup-devops-cli/internal/privilege"
+	"github.com/matheusflausino/setup-devops-cli/internal/utils"
+)
+
+// InitSystem é a interface comum aos sistemas de init
+type InitSystem interface {
+	// Name retorna o nome do sistema de init (systemd, openrc, none)
+	Name() string
+	// Managed indica se há um init capaz de gerenciar serviços
+	Managed() bool
+	// Start inicia o serviço
+	Start(service string) error
+	// Enable habilita o serviço no boot
+	Enable(service string) error
+	// Restart reinicia o serviço para aplicar nova configuração
+	Restart(service string) error
+	// IsActive verifica se o serviço está em execução
+	IsActive(service string) bool
+}
+
+// Detect retorna o sistema de init em execução. Containers e o WSL sem
+// systemd não têm init gerenciando serviços.
+func Detect() InitSystem {
+	if _, err := os.Stat("/run/systemd/system"); err == nil {
+		return systemd{}
+	}
+	if _, err := os.Stat("/run/openrc/softlevel"); err == nil {
+		return openrc{}
+	}
+	if _, err := exec.LookPath("rc-service"); err == nil && !utils.InContainer() {
+		return openrc{}
+	}
+	return none{}
+}
+
+// systemd gerencia serviços com systemctl
+type systemd struct{}
+
+func (systemd) Name() string  { return "systemd" }
+func (systemd) Managed() bool { return true }
+
+func (systemd) Start(service string) error {
+	return privilege.Run("systemctl", "start", service)
+}
+
+func (systemd) Enable(service string) error {
+	return privilege.Run("systemctl", "enable", service)
+}
+
+func (systemd) Restart(service string) error {
+	return privilege.Run("systemctl", "restart", service)
+}
+
+func (systemd) IsActive(service string) bool {
+	return utils.RunCommand("systemctl", "is-active", "--quiet", service) == nil
+}
+
+// openrc gerencia serviços com rc-service e rc-update (Alpine, Gentoo)
+type openrc struct{}
+
+func (openrc) Name() string  { return "openrc" }
+func (openrc) Managed() bool { return true }
+
+func (openrc) Start(service string) error {
+	return privilege.Run("rc-service", service, "start")
+}
+
+func (openrc) Enable(service string) error {
+	return privilege.Run("rc-update", "add", service, "default")
+}
+
+func (openrc) Restart(service string) error {
+	return privilege.Run("rc-service", service, "restart")
+}
+
+func (openrc) IsActive(service string) bool {
+	return utils.RunCommand("rc-service", service, "status") == nil
+}
+
+// none representa ambientes sem init gerenciando serviços (containers, WSL
+// sem systemd); os serviços precisam ser iniciados manualmente
+type none struct{}
+
+func (none) Name() string  { return "none" }
+func (none) Managed() bool { return false }
+
+func (none) Start(service string) error {
+	return fmt.Errorf("nenhum sistema de init disponível para iniciar %s", service)
+}
+
+func (none) Enable(service string) error {
+	return fmt.Errorf("nenhum sistema de init disponível para habilitar %s", service)
+}
+
+func (none) Restart(service string) error {
+	return fmt.Errorf("nenhum sistema de init disponível para reiniciar %s", service)
+}
+
+func (none) IsActive(service string) bool {
+	return false
+}
@@ -0,0 +1,68 @@
+package installer
+
+import (
+	"fmt"
+
+	"github.com/matheusflausino/setup-devops-cli/internal/bundle"
+	"github.com/matheusflausino/setup-devops-cli/internal/pkgmgr"
+	"github.com/matheusflausino/setup-devops-cli/internal/utils"
+)
+
+// Artifacts lista o que precisa ser baixado para instalar a ferramenta sem
+// acesso à rede no sistema e arquitetura informados
+func Artifacts(tool string, osType utils.OSType, arch string) ([]bundle.Item, error) {
+	if osType == utils.MacOS {
+		return nil, fmt.Errorf("bundles offline não são suportados no macOS (Homebrew)")
+	}
+
+	osInfo := &utils.OSInfo{Type: osType, Arch: arch}
+	pm, err := pkgmgr.New(pkgmgr.NameFor(osInfo))
+	if err != nil {
+		return nil, err
+	}
+
+	var items []bundle.Item
+	var pkgs []string
+
+	if usesPackages(tool, osInfo) {
+		spec, _ := toolSpec(tool, osInfo)
+		pkgs = spec.For(osType, pm.Name())
+
+		// Pacotes .deb não são assinados individualmente; a chave do fornecedor
+		// só é usada offline pelos gerenciadores que verificam os pacotes (rpm)
+		if _, verifies := pm.(pkgmgr.KeyImporter); verifies && spec.Repository != nil {
+			if repo := spec.Repository(osInfo); repo != nil && repo.KeyURL != "" {
+				items = append(items, bundle.Item{Tool: tool, Kind: bundle.KindKey, URL: repo.KeyURL})
+			}
+		}
+	} else if _, ok := releases[tool]; ok {
+		rel := releaseFor(tool)
+		pkgs = helperPackages[tool]
+		items = append(items, bundle.Item{Tool: tool, Kind: bundle.KindRelease, URL: rel.downloadURL("linux", arch)})
+		if rel.Checksums != "" {
+			items = append(items, bundle.Item{Tool: tool, Kind: bundle.KindRelease, URL: rel.expand(rel.Checksums, "linux", arch)})
+		}
+		if rel.Signature != "" {
+			items = append(items,
+				bundle.Item{Tool: tool, Kind: bundle.KindRelease, URL: rel.expand(rel.Signature, "linux", arch)},
+				bundle.Item{Tool: tool, Kind: bundle.KindKey, URL: rel.KeyURL})
+			pkgs = append(append([]string{}, pkgs...), gpgPackage(osInfo))
+		}
+	} else if tool == "aws-cli" {
+		items = append(items, bundle.Item{Tool: tool, Kind: bundle.KindRelease, URL: awsCLIURL(arch)})
+		pkgs = helperPackages[tool]
+	}
+
+	if len(pkgs) > 0 {
+		items = append(items, bundle.Item{Tool: tool, Kind: bundle.KindPackage, Packages: pkgs})
+	}
+
+	// Plugins da CLI do Docker (compose, buildx)
+	if tool == "docker" && usesDockerPlugins(osInfo) {
+		for _, plugin := range dockerPlugins {
+			items = append(items, bundle.Item{Tool: tool, Kind: bundle.KindRelease, URL: plugin.Release.downloadURL("linux", arch)})
+		}
+	}
+
+	return items, nil
+}
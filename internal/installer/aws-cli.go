@@ -2,13 +2,17 @@ package installer
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
 
 	"github.com/fatih/color"
+	"github.com/matheusflausino/setup-devops-cli/internal/download"
 	"github.com/matheusflausino/setup-devops-cli/internal/utils"
 )
 
 // installAWSCLI instala o AWS CLI no sistema
-func installAWSCLI(osType utils.OSType) error {
+func installAWSCLI(osInfo *utils.OSInfo) error {
 	if isCommandAvailable("aws") {
 		color.Yellow("⚠️  AWS CLI já está instalado")
 		return nil
@@ -16,101 +20,72 @@ func installAWSCLI(osType utils.OSType) error {
 
 	color.Green("☁️  Instalando AWS CLI...")
 
-	switch osType {
-	case utils.Ubuntu:
-		return installAWSCLIUbuntu()
-	case utils.CentOS:
-		return installAWSCLICentOS()
-	case utils.MacOS:
-		return installAWSCLIMacOS()
+	switch {
+	case usesPackages("aws-cli", osInfo):
+		return installPackageTool("aws-cli", osInfo)
+	case osInfo.Type.IsLinux():
+		return installAWSCLILinux(osInfo)
 	default:
-		return fmt.Errorf("sistema operacional não suportado para instalação do AWS CLI: %s", osType)
+		return fmt.Errorf("sistema operacional não suportado para instalação do AWS CLI: %s", osInfo)
 	}
 }
 
-// installAWSCLIUbuntu instala AWS CLI no Ubuntu
-func installAWSCLIUbuntu() error {
-	color.Blue("📦 Instalando AWS CLI no Ubuntu...")
-
-	// Baixar o instalador
-	if err := utils.RunCommand("curl", "https://awscli.amazonaws.com/awscli-exe-linux-x86_64.zip", "-o", "awscliv2.zip"); err != nil {
-		return fmt.Errorf("erro ao baixar AWS CLI: %w", err)
-	}
+// installAWSCLILinux instala o AWS CLI com o instalador oficial
+func installAWSCLILinux(osInfo *utils.OSInfo) error {
+	color.Blue("📦 Instalando AWS CLI em %s...", osInfo)
 
 	// Instalar unzip se não estiver disponível
 	if !isCommandAvailable("unzip") {
-		if err := utils.RunCommand("sudo", "apt-get", "update"); err != nil {
-			return fmt.Errorf("erro ao atualizar repositórios: %w", err)
-		}
-		if err := utils.RunCommand("sudo", "apt-get", "install", "-y", "unzip"); err != nil {
+		if err := installHelperPackages("aws-cli", osInfo); err != nil {
 			return fmt.Errorf("erro ao instalar unzip: %w", err)
 		}
 	}
 
-	// Extrair o instalador
-	if err := utils.RunCommand("unzip", "awscliv2.zip"); err != nil {
-		return fmt.Errorf("erro ao extrair AWS CLI: %w", err)
+	if err := installAWSCLIBundle(); err != nil {
+		return err
 	}
 
-	// Instalar AWS CLI
-	if err := utils.RunCommand("sudo", "./aws/install"); err != nil {
-		return fmt.Errorf("erro ao instalar AWS CLI: %w", err)
-	}
+	color.Green("✅ AWS CLI instalado com sucesso!")
+	return nil
+}
 
-	// Limpar arquivos temporários
-	utils.RunCommandSilent("rm", "-rf", "awscliv2.zip", "aws")
+// awsCLIArch traduz a arquitetura do Go para o nome usado pelo instalador do AWS CLI
+var awsCLIArch = map[string]string{"amd64": "x86_64", "arm64": "aarch64"}
 
-	color.Green("✅ AWS CLI instalado com sucesso no Ubuntu!")
-	return nil
+// awsCLIURL retorna a URL do instalador do AWS CLI para a arquitetura informada
+func awsCLIURL(arch string) string {
+	if name, ok := awsCLIArch[arch]; ok {
+		arch = name
+	}
+	return fmt.Sprintf("https://awscli.amazonaws.com/awscli-exe-linux-%s.zip", arch)
 }
 
-// installAWSCLICentOS instala AWS CLI no CentOS/RHEL
-func installAWSCLICentOS() error {
-	color.Blue("📦 Instalando AWS CLI no CentOS/RHEL...")
+// installAWSCLIBundle baixa o instalador oficial e instala o AWS CLI no prefixo.
+// O próprio instalador mantém as versões em <prefix>/opt/aws-cli/v2/<version>
+// e atualiza o symlink "current" e os links em <prefix>/bin.
+func installAWSCLIBundle() error {
+	tmpDir, err := os.MkdirTemp("", "setup-devops-aws-cli-")
+	if err != nil {
+		return fmt.Errorf("erro ao criar diretório temporário: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	zipFile := filepath.Join(tmpDir, "awscliv2.zip")
 
 	// Baixar o instalador
-	if err := utils.RunCommand("curl", "https://awscli.amazonaws.com/awscli-exe-linux-x86_64.zip", "-o", "awscliv2.zip"); err != nil {
+	if err := download.Fetch("aws-cli", awsCLIURL(runtime.GOARCH), zipFile); err != nil {
 		return fmt.Errorf("erro ao baixar AWS CLI: %w", err)
 	}
 
-	// Instalar unzip se não estiver disponível
-	if !isCommandAvailable("unzip") {
-		if err := utils.RunCommand("sudo", "yum", "install", "-y", "unzip"); err != nil {
-			return fmt.Errorf("erro ao instalar unzip: %w", err)
-		}
-	}
-
 	// Extrair o instalador
-	if err := utils.RunCommand("unzip", "awscliv2.zip"); err != nil {
+	if err := utils.RunCommand("unzip", "-q", zipFile, "-d", tmpDir); err != nil {
 		return fmt.Errorf("erro ao extrair AWS CLI: %w", err)
 	}
 
 	// Instalar AWS CLI
-	if err := utils.RunCommand("sudo", "./aws/install"); err != nil {
-		return fmt.Errorf("erro ao instalar AWS CLI: %w", err)
-	}
-
-	// Limpar arquivos temporários
-	utils.RunCommandSilent("rm", "-rf", "awscliv2.zip", "aws")
-
-	color.Green("✅ AWS CLI instalado com sucesso no CentOS/RHEL!")
-	return nil
-}
-
-// installAWSCLIMacOS instala AWS CLI no macOS
-func installAWSCLIMacOS() error {
-	color.Blue("📦 Instalando AWS CLI no macOS...")
-
-	// Verificar se Homebrew está instalado
-	if !isCommandAvailable("brew") {
-		return fmt.Errorf("Homebrew não está instalado. Instale primeiro: https://brew.sh")
-	}
-
-	// Instalar AWS CLI via Homebrew
-	if err := utils.RunCommand("brew", "install", "awscli"); err != nil {
+	if err := runInPrefix(filepath.Join(tmpDir, "aws", "install"), "--install-dir", toolDir("aws-cli"), "--bin-dir", binDir(), "--update"); err != nil {
 		return fmt.Errorf("erro ao instalar AWS CLI: %w", err)
 	}
 
-	color.Green("✅ AWS CLI instalado com sucesso no macOS!")
 	return nil
 }
@@ -0,0 +1,60 @@
+package installer
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/fatih/color"
+	"github.com/matheusflausino/setup-devops-cli/internal/utils"
+)
+
+// azPipVersion é a versão do Azure CLI instalada com pip onde não há pacote
+const azPipVersion = "2.65.0"
+
+// installAz instala o Azure CLI no sistema
+func installAz(osInfo *utils.OSInfo) error {
+	if isCommandAvailable("az") {
+		color.Yellow("⚠️  Azure CLI já está instalado")
+		return nil
+	}
+
+	color.Green("☁️  Instalando Azure CLI...")
+
+	switch {
+	case usesPackages("az", osInfo):
+		return installPackageTool("az", osInfo)
+	case osInfo.Type == utils.Alpine:
+		return installAzVirtualenv(osInfo)
+	default:
+		return fmt.Errorf("sistema operacional não suportado para instalação do Azure CLI: %s", osInfo)
+	}
+}
+
+// installAzVirtualenv instala o Azure CLI com pip em um virtualenv no layout
+// versionado do prefixo (<prefix>/opt/az/<version>)
+func installAzVirtualenv(osInfo *utils.OSInfo) error {
+	dir := toolVersionDir("az", azPipVersion)
+	color.Blue("📦 Instalando Azure CLI %s em %s...", azPipVersion, dir)
+
+	if err := installPython(osInfo, "az"); err != nil {
+		return err
+	}
+
+	if err := runInPrefix("mkdir", "-p", filepath.Dir(dir), binDir()); err != nil {
+		return fmt.Errorf("erro ao criar diretório %s: %w", dir, err)
+	}
+	if err := runInPrefix("python3", "-m", "venv", dir); err != nil {
+		return fmt.Errorf("erro ao criar virtualenv do Azure CLI: %w", err)
+	}
+	if err := runInPrefix(filepath.Join(dir, "bin", "pip"), "install", "--no-cache-dir", "azure-cli=="+azPipVersion); err != nil {
+		runInPrefixSilent("rm", "-rf", dir)
+		return fmt.Errorf("erro ao instalar Azure CLI com pip: %w", err)
+	}
+
+	if err := activateVersion("az", azPipVersion, "bin/az"); err != nil {
+		return err
+	}
+
+	color.Green("✅ Azure CLI %s instalado com sucesso em %s!", azPipVersion, binDir())
+	return nil
+}
@@ -0,0 +1,68 @@
+package installer
+
+import (
+	"github.com/matheusflausino/setup-devops-cli/internal/config"
+	"github.com/matheusflausino/setup-devops-cli/internal/utils"
+)
+
+// Component descreve um subcomponente de uma ferramenta (plugin) e sua
+// versão instalada ("" quando ausente)
+type Component struct {
+	Name    string
+	Version string
+	// Managed é a versão gerenciada pela CLI ("" quando não é fixada)
+	Managed string
+}
+
+// ToolComponents retorna os subcomponentes da ferramenta: plugins do Docker,
+// plugins do Helm (no helm) e plugins do kubectl instalados pelo krew
+func ToolComponents(tool string) []Component {
+	switch tool {
+	case "docker":
+		return dockerComponents()
+	case "helm":
+		return helmPluginComponents()
+	case "krew":
+		return krewPluginComponents()
+	default:
+		return nil
+	}
+}
+
+// HasComponents indica se a ferramenta tem subcomponentes gerenciados (ou
+// plugins do tflint a inicializar), que são instalados e atualizados mesmo
+// com a ferramenta já instalada
+func HasComponents(tool string) bool {
+	switch tool {
+	case "docker":
+		return true
+	case "helm", "helmfile":
+		return len(helmPlugins()) > 0
+	case "kubectl", "krew":
+		return len(config.KrewPlugins()) > 0
+	case "tflint":
+		return tflintConfigFile() != ""
+	default:
+		return false
+	}
+}
+
+// installComponents instala ou atualiza os subcomponentes da ferramenta (no
+// Docker, também reaplica a configuração do daemon)
+func installComponents(tool string, osInfo *utils.OSInfo) error {
+	switch tool {
+	case "docker":
+		if err := reconfigureDockerDaemon(osInfo); err != nil {
+			return err
+		}
+		return installDockerPlugins(osInfo)
+	case "helm", "helmfile":
+		return installHelmPlugins()
+	case "kubectl", "krew":
+		return installKrewPlugins(osInfo)
+	case "tflint":
+		return initTflint()
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,153 @@
+package installer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/matheusflausino/setup-devops-cli/internal/config"
+	"github.com/matheusflausino/setup-devops-cli/internal/privilege"
+	"github.com/matheusflausino/setup-devops-cli/internal/utils"
+)
+
+// dockerDaemonConfig é o arquivo de configuração do daemon do Docker
+const dockerDaemonConfig = "/etc/docker/daemon.json"
+
+// configureDockerDaemon mescla a seção docker.daemon da configuração em
+// daemon.json, preservando as chaves que o perfil não define. Retorna true
+// quando o arquivo foi alterado e o daemon precisa ser reiniciado.
+func configureDockerDaemon() (bool, error) {
+	content, err := dockerDaemonContent()
+	if err != nil || content == nil {
+		return false, err
+	}
+
+	if err := validateDaemonConfig(content); err != nil {
+		return false, err
+	}
+
+	changed, err := privilege.WriteFile(dockerDaemonConfig, string(content), 0o644)
+	if err != nil {
+		return false, err
+	}
+	if changed {
+		color.Green("✅ Configuração do daemon do Docker aplicada em %s", dockerDaemonConfig)
+	}
+	return changed, nil
+}
+
+// dockerDaemonPending verifica se docker.daemon ainda não está aplicado em
+// daemon.json, para listar a escrita entre os comandos privilegiados
+func dockerDaemonPending() bool {
+	content, err := dockerDaemonContent()
+	return err == nil && content != nil
+}
+
+// dockerDaemonContent retorna daemon.json com docker.daemon mesclado, ou nil
+// quando não há configuração ou o conteúdo atual já é equivalente
+func dockerDaemonContent() ([]byte, error) {
+	settings := config.DockerDaemon()
+	if len(settings) == 0 {
+		return nil, nil
+	}
+
+	current := map[string]interface{}{}
+	data, err := os.ReadFile(dockerDaemonConfig)
+	switch {
+	case err == nil:
+		if len(bytes.TrimSpace(data)) > 0 {
+			if err := json.Unmarshal(data, &current); err != nil {
+				return nil, fmt.Errorf("%s contém JSON inválido; corrija-o antes de aplicar docker.daemon: %w", dockerDaemonConfig, err)
+			}
+		}
+	case !os.IsNotExist(err):
+		return nil, fmt.Errorf("erro ao ler %s: %w", dockerDaemonConfig, err)
+	}
+
+	merged, err := normalizeJSON(mergeDaemonConfig(current, settings))
+	if err != nil {
+		return nil, fmt.Errorf("configuração docker.daemon inválida: %w", err)
+	}
+
+	// Mesmo conteúdo com outra formatação não exige reiniciar o daemon
+	if reflect.DeepEqual(current, merged) {
+		return nil, nil
+	}
+
+	content, err := json.MarshalIndent(merged, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("erro ao gerar %s: %w", dockerDaemonConfig, err)
+	}
+	return append(content, '\n'), nil
+}
+
+// mergeDaemonConfig aplica settings sobre current: objetos são mesclados
+// recursivamente e os demais valores (inclusive listas) são substituídos
+func mergeDaemonConfig(current, settings map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(current)+len(settings))
+	for key, value := range current {
+		merged[key] = value
+	}
+
+	for key, value := range settings {
+		existing, okExisting := merged[key].(map[string]interface{})
+		override, okOverride := value.(map[string]interface{})
+		if okExisting && okOverride {
+			merged[key] = mergeDaemonConfig(existing, override)
+			continue
+		}
+		merged[key] = value
+	}
+	return merged
+}
+
+// normalizeJSON converte os valores lidos da configuração para os tipos que
+// o encoding/json produz, permitindo comparar com o daemon.json atual
+func normalizeJSON(value map[string]interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	normalized := map[string]interface{}{}
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return nil, err
+	}
+	return normalized, nil
+}
+
+// validateDaemonConfig valida o arquivo com o próprio dockerd quando ele
+// oferece --validate (Docker 23+), detectando opções desconhecidas ou
+// conflitantes antes de reiniciar o daemon
+func validateDaemonConfig(content []byte) error {
+	if !isCommandAvailable("dockerd") {
+		return nil
+	}
+
+	tmp, err := os.CreateTemp("", "setup-devops-daemon-*.json")
+	if err != nil {
+		return fmt.Errorf("erro ao criar arquivo temporário: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(content); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("erro ao gravar arquivo temporário: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	output, err := utils.RunCommandCapture("dockerd", "--validate", "--config-file", tmp.Name())
+	if err != nil {
+		if strings.Contains(output, "unknown flag") {
+			return nil
+		}
+		return fmt.Errorf("configuração docker.daemon rejeitada pelo dockerd: %s", strings.TrimSpace(output))
+	}
+	return nil
+}
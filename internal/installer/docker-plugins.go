@@ -0,0 +1,208 @@
+package installer
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/matheusflausino/setup-devops-cli/internal/config"
+	"github.com/matheusflausino/setup-devops-cli/internal/utils"
+)
+
+// dockerPlugin é um plugin da CLI do Docker gerenciado como subcomponente
+// versionado da ferramenta docker
+type dockerPlugin struct {
+	// Name é o subcomando do docker (docker compose, docker buildx)
+	Name    string
+	Release binaryRelease
+}
+
+// dockerPlugins são os plugins instalados junto com o Docker, no layout
+// versionado do prefixo e ativados no diretório de plugins da CLI
+var dockerPlugins = []dockerPlugin{
+	{
+		Name: "compose",
+		Release: binaryRelease{
+			Tool:    "docker-compose",
+			Version: "v2.29.7",
+			URL:     "https://github.com/docker/compose/releases/download/{version}/docker-compose-{os}-{arch}",
+			Binary:  "docker-compose",
+			Arch:    map[string]string{"amd64": "x86_64", "arm64": "aarch64"},
+		},
+	},
+	{
+		Name: "buildx",
+		Release: binaryRelease{
+			Tool:    "docker-buildx",
+			Version: "v0.17.1",
+			URL:     "https://github.com/docker/buildx/releases/download/{version}/buildx-{version}.{os}-{arch}",
+			Binary:  "docker-buildx",
+		},
+	},
+}
+
+// dockerPluginsDir retorna o diretório de plugins da CLI do Docker no Linux:
+// o do sistema para os prefixos padrão e ~/.docker/cli-plugins nos demais casos
+func dockerPluginsDir() string {
+	prefix := config.Prefix()
+	if prefix == config.DefaultPrefix || prefix == "/usr" {
+		return filepath.Join(prefix, "lib", "docker", "cli-plugins")
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(prefix, "lib", "docker", "cli-plugins")
+	}
+	return filepath.Join(home, ".docker", "cli-plugins")
+}
+
+// usesDockerPlugins verifica se o provedor do Docker usa os plugins da CLI
+// gerenciados. O Podman tem os próprios equivalentes; no macOS o Docker
+// Desktop mantém os dele em ~/.docker/cli-plugins e o Colima usa os do
+// Homebrew; e a integração WSL do Docker Desktop fornece os dela.
+func usesDockerPlugins(osInfo *utils.OSInfo) bool {
+	if !osInfo.Type.IsLinux() {
+		return false
+	}
+	if osInfo.WSL > 0 && utils.DockerDesktopIntegration() {
+		return false
+	}
+	return selectDockerProvider(osInfo) != providerPodman
+}
+
+// brewDockerPlugins registra o diretório de plugins do Homebrew (compose e
+// buildx instalados com o Colima) em cliPluginsExtraDirs de
+// ~/.docker/config.json, sem tocar nos links de ~/.docker/cli-plugins
+func brewDockerPlugins() error {
+	output, err := utils.RunCommandOutput("brew", "--prefix")
+	if err != nil {
+		return fmt.Errorf("erro ao localizar o Homebrew: %w", err)
+	}
+	pluginsDir := filepath.Join(strings.TrimSpace(output), "lib", "docker", "cli-plugins")
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return fmt.Errorf("erro ao localizar o diretório home: %w", err)
+	}
+	path := filepath.Join(home, ".docker", "config.json")
+
+	cliConfig := map[string]interface{}{}
+	data, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		if len(strings.TrimSpace(string(data))) > 0 {
+			if err := json.Unmarshal(data, &cliConfig); err != nil {
+				return fmt.Errorf("%s contém JSON inválido: %w", path, err)
+			}
+		}
+	case !os.IsNotExist(err):
+		return fmt.Errorf("erro ao ler %s: %w", path, err)
+	}
+
+	dirs, _ := cliConfig["cliPluginsExtraDirs"].([]interface{})
+	for _, dir := range dirs {
+		if dir == pluginsDir {
+			return nil
+		}
+	}
+	cliConfig["cliPluginsExtraDirs"] = append(dirs, pluginsDir)
+
+	content, err := json.MarshalIndent(cliConfig, "", "  ")
+	if err != nil {
+		return fmt.Errorf("erro ao gerar %s: %w", path, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("erro ao criar diretório %s: %w", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, append(content, '\n'), 0o600); err != nil {
+		return fmt.Errorf("erro ao gravar %s: %w", path, err)
+	}
+
+	color.Green("✅ Plugins do Docker do Homebrew registrados em %s", path)
+	return nil
+}
+
+// installDockerPlugins instala as versões gerenciadas dos plugins que ainda
+// não estão ativas no diretório de plugins
+func installDockerPlugins(osInfo *utils.OSInfo) error {
+	if !usesDockerPlugins(osInfo) {
+		return nil
+	}
+
+	dir := dockerPluginsDir()
+	for _, plugin := range dockerPlugins {
+		rel := plugin.Release
+		target := filepath.Join(toolVersionDir(rel.Tool, rel.Version), rel.Binary)
+		if current, err := os.Readlink(filepath.Join(dir, rel.Binary)); err == nil && current == target {
+			continue
+		}
+
+		if err := installDockerPlugin(dir, rel); err != nil {
+			return err
+		}
+		color.Green("✅ docker %s %s instalado em %s", plugin.Name, rel.Version, dir)
+	}
+	return nil
+}
+
+// installDockerPlugin baixa o plugin e o ativa no diretório de plugins
+func installDockerPlugin(dir string, rel binaryRelease) error {
+	tmpDir, err := os.MkdirTemp("", "setup-devops-"+rel.Tool+"-")
+	if err != nil {
+		return fmt.Errorf("erro ao criar diretório temporário: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	binary, err := fetchRelease(rel, tmpDir)
+	if err != nil {
+		return err
+	}
+
+	if err := installVersionedBinaryIn(dir, rel.Tool, rel.Version, binary, rel.Binary); err != nil {
+		return fmt.Errorf("erro ao instalar %s: %w", rel.Tool, err)
+	}
+	return nil
+}
+
+// dockerComponents retorna os plugins do Docker com as versões encontradas
+// pela CLI do Docker (que também considera plugins de pacotes). No macOS os
+// plugins vêm do Docker Desktop ou do Homebrew e não têm versão gerenciada.
+func dockerComponents() []Component {
+	if !isCommandAvailable("docker") || dockerIsPodman() {
+		return nil
+	}
+
+	components := make([]Component, 0, len(dockerPlugins))
+	for _, plugin := range dockerPlugins {
+		managed := plugin.Release.Version
+		if runtime.GOOS != "linux" {
+			managed = ""
+		}
+		components = append(components, Component{
+			Name:    plugin.Name,
+			Version: dockerPluginVersion(plugin.Name),
+			Managed: managed,
+		})
+	}
+	return components
+}
+
+// dockerPluginVersion retorna a versão do plugin ("docker compose version")
+func dockerPluginVersion(name string) string {
+	output, err := utils.RunCommandOutput("docker", name, "version")
+	if err != nil {
+		return ""
+	}
+
+	// "Docker Compose version v2.29.7" / "github.com/docker/buildx v0.17.1 5761a8..."
+	for _, field := range strings.Fields(output) {
+		if strings.HasPrefix(field, "v") && strings.Count(field, ".") >= 2 {
+			return field
+		}
+	}
+	return strings.TrimSpace(output)
+}
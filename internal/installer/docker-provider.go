@@ -0,0 +1,156 @@
+package installer
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/matheusflausino/setup-devops-cli/internal/config"
+	"github.com/matheusflausino/setup-devops-cli/internal/pkgmgr"
+	"github.com/matheusflausino/setup-devops-cli/internal/privilege"
+	"github.com/matheusflausino/setup-devops-cli/internal/utils"
+)
+
+// Provedores que satisfazem a ferramenta "docker"
+const (
+	providerDockerCE      = "docker-ce"
+	providerPodman        = "podman"
+	providerDockerDesktop = "docker-desktop"
+	providerColima        = "colima"
+)
+
+// dockerProviders associa cada provedor ao teste dos sistemas que o suportam
+var dockerProviders = map[string]func(osInfo *utils.OSInfo) bool{
+	providerDockerCE:      isLinux,
+	providerPodman:        isLinux,
+	providerDockerDesktop: isMacOS,
+	providerColima:        isMacOS,
+}
+
+// selectDockerProvider escolhe o provedor do Docker: o configurado em
+// docker.provider ou, em modo auto, o já instalado, o Colima no macOS se o
+// comando existir e, por padrão, Docker Desktop no macOS e Docker CE no
+// Linux. O Podman, que vem pré-instalado no Fedora e no RHEL, só é usado
+// quando configurado ou quando o comando docker já é o podman-docker.
+func selectDockerProvider(osInfo *utils.OSInfo) string {
+	if provider := config.DockerProvider(); provider != "auto" {
+		return provider
+	}
+
+	if installed := InstalledDockerProvider(); installed != "" && dockerProviders[installed](osInfo) {
+		return installed
+	}
+
+	if osInfo.Type == utils.MacOS {
+		if isCommandAvailable("colima") {
+			return providerColima
+		}
+		return providerDockerDesktop
+	}
+	return providerDockerCE
+}
+
+// checkDockerProvider valida o provedor escolhido para o sistema
+func checkDockerProvider(provider string, osInfo *utils.OSInfo) error {
+	supports, ok := dockerProviders[provider]
+	if !ok {
+		return fmt.Errorf("provedor do Docker inválido: %q (use auto, docker-ce, podman, docker-desktop ou colima)", provider)
+	}
+	if !supports(osInfo) {
+		return fmt.Errorf("provedor do Docker %s não é suportado em %s", provider, osInfo)
+	}
+	return nil
+}
+
+// InstalledDockerProvider retorna o provedor que fornece o Docker no
+// sistema, ou "" quando nenhum está instalado
+func InstalledDockerProvider() string {
+	if utils.WSLVersion() > 0 && utils.DockerDesktopIntegration() {
+		return providerDockerDesktop
+	}
+
+	if isCommandAvailable("docker") {
+		switch {
+		case dockerIsPodman():
+			return providerPodman
+		case colimaConfigured():
+			return providerColima
+		case dockerDesktopInstalled():
+			return providerDockerDesktop
+		default:
+			return providerDockerCE
+		}
+	}
+
+	// Sem o comando docker, o Podman só satisfaz a ferramenta com o pacote
+	// podman-docker; do contrário a instalação adiciona o atalho
+	if isCommandAvailable("podman") && podmanDockerInstalled() {
+		return providerPodman
+	}
+	return ""
+}
+
+// ToolProvider retorna o provedor que satisfaz a ferramenta, para as que
+// têm mais de um (ex: docker via podman), ou "" para as demais
+func ToolProvider(tool string) string {
+	if tool == "docker" {
+		return InstalledDockerProvider()
+	}
+	return ""
+}
+
+// dockerIsPodman verifica se o comando docker é o atalho do podman-docker
+func dockerIsPodman() bool {
+	output, err := utils.RunCommandOutput("docker", "--version")
+	return err == nil && strings.Contains(strings.ToLower(output), "podman")
+}
+
+// podmanDockerInstalled verifica se o pacote podman-docker está instalado
+func podmanDockerInstalled() bool {
+	osInfo, err := utils.GetOSInfo()
+	if err != nil || !osInfo.Type.IsLinux() {
+		return false
+	}
+
+	pm, err := pkgmgr.ForOS(osInfo)
+	return err == nil && pm.IsInstalled("podman-docker")
+}
+
+// colimaConfigured verifica se o comando docker usa uma VM do Colima, pelo
+// contexto ativo criado pelo "colima start" (colima ou colima-<perfil>)
+func colimaConfigured() bool {
+	if !isCommandAvailable("colima") {
+		return false
+	}
+
+	output, err := utils.RunCommandOutput("docker", "context", "show")
+	return err == nil && strings.HasPrefix(strings.TrimSpace(output), "colima")
+}
+
+// dockerDesktopInstalled verifica se o Docker Desktop está instalado no macOS
+func dockerDesktopInstalled() bool {
+	_, err := os.Stat("/Applications/Docker.app")
+	return err == nil
+}
+
+// configurePodman silencia o aviso do podman-docker a cada comando docker
+func configurePodman(osInfo *utils.OSInfo) error {
+	if _, err := privilege.WriteFile("/etc/containers/nodocker", "", 0o644); err != nil {
+		return fmt.Errorf("erro ao configurar podman-docker: %w", err)
+	}
+
+	color.Green("✅ Podman instalado; o comando docker usa o podman (sem daemon)")
+	return nil
+}
+
+// configureColima registra os plugins da CLI do Homebrew e orienta como
+// iniciar a VM do Colima
+func configureColima(osInfo *utils.OSInfo) error {
+	if err := brewDockerPlugins(); err != nil {
+		return err
+	}
+
+	color.Yellow("⚠️  IMPORTANTE: Inicie a VM do Colima para usar o Docker: colima start")
+	return nil
+}
@@ -2,130 +2,247 @@ package installer
 
 import (
 	"fmt"
+	"os"
+	"os/user"
+	"strings"
 
 	"github.com/fatih/color"
+	"github.com/matheusflausino/setup-devops-cli/internal/initsys"
+	"github.com/matheusflausino/setup-devops-cli/internal/privilege"
 	"github.com/matheusflausino/setup-devops-cli/internal/utils"
 )
 
-// installDocker instala o Docker no sistema
-func installDocker(osType utils.OSType) error {
-	if isCommandAvailable("docker") {
-		color.Yellow("⚠️  Docker já está instalado")
+// installDocker instala o Docker no sistema com o provedor escolhido
+// (docker-ce, podman, docker-desktop ou colima)
+func installDocker(osInfo *utils.OSInfo) error {
+	provider, skip, err := checkDockerInstall(osInfo)
+	if err != nil {
+		return err
+	}
+	if skip != "" {
+		color.Yellow("⚠️  %s", skip)
 		return nil
 	}
 
-	color.Green("🐳 Instalando Docker...")
+	color.Green("🐳 Instalando Docker (%s)...", provider)
 
-	switch osType {
-	case utils.Ubuntu:
-		return installDockerUbuntu()
-	case utils.CentOS:
-		return installDockerCentOS()
-	case utils.MacOS:
+	switch provider {
+	case providerDockerDesktop:
+		// O Docker Desktop traz e mantém os próprios plugins
 		return installDockerMacOS()
 	default:
-		return fmt.Errorf("sistema operacional não suportado para instalação do Docker: %s", osType)
+		return installDockerPackages(osInfo)
 	}
 }
 
-// installDockerUbuntu instala Docker no Ubuntu
-func installDockerUbuntu() error {
-	color.Blue("📦 Instalando Docker no Ubuntu...")
+// checkDockerInstall escolhe e valida o provedor do Docker antes de qualquer
+// instalação (individual ou em lote). Retorna o motivo para não instalar
+// quando o Docker já é fornecido de outra forma.
+func checkDockerInstall(osInfo *utils.OSInfo) (provider, skip string, err error) {
+	if osInfo.WSL > 0 && utils.DockerDesktopIntegration() {
+		return "", "Docker já é fornecido pela integração WSL do Docker Desktop", nil
+	}
 
-	// Atualizar repositórios
-	if err := utils.RunCommand("sudo", "apt-get", "update"); err != nil {
-		return fmt.Errorf("erro ao atualizar repositórios: %w", err)
+	if installed := InstalledDockerProvider(); installed != "" {
+		return "", fmt.Sprintf("Docker já está instalado (%s)", installed), nil
 	}
 
-	// Instalar dependências
-	deps := []string{"apt-transport-https", "ca-certificates", "curl", "gnupg", "lsb-release"}
-	for _, dep := range deps {
-		if err := utils.RunCommand("sudo", "apt-get", "install", "-y", dep); err != nil {
-			return fmt.Errorf("erro ao instalar dependência %s: %w", dep, err)
-		}
+	provider = selectDockerProvider(osInfo)
+	if err := checkDockerProvider(provider, osInfo); err != nil {
+		return "", "", err
 	}
 
-	// Adicionar chave GPG oficial do Docker
-	if err := utils.RunCommand("curl", "-fsSL", "https://download.docker.com/linux/ubuntu/gpg", "|", "sudo", "gpg", "--dearmor", "-o", "/usr/share/keyrings/docker-archive-keyring.gpg"); err != nil {
-		return fmt.Errorf("erro ao adicionar chave GPG do Docker: %w", err)
+	// Com o Docker Desktop no Windows, instalar o Docker Engine na distribuição
+	// cria um segundo daemon concorrente; o recomendado é ativar a integração
+	if provider == providerDockerCE && osInfo.WSL > 0 && utils.DockerDesktopOnWindows() {
+		return "", fmt.Sprintf("Docker Desktop detectado no Windows: ative a integração com %s em Settings > Resources > WSL Integration em vez de instalar o Docker Engine", utils.WSLDistro()), nil
 	}
 
-	// Adicionar repositório do Docker
-	if err := utils.RunCommand("bash", "-c", `echo "deb [arch=$(dpkg --print-architecture) signed-by=/usr/share/keyrings/docker-archive-keyring.gpg] https://download.docker.com/linux/ubuntu $(lsb_release -cs) stable" | sudo tee /etc/apt/sources.list.d/docker.list > /dev/null`); err != nil {
-		return fmt.Errorf("erro ao adicionar repositório do Docker: %w", err)
+	return provider, "", nil
+}
+
+// installDockerPackages instala o provedor do Docker (Docker CE, Podman ou
+// Colima) com o gerenciador de pacotes do sistema (sem acesso à rede os
+// pacotes vêm do bundle)
+func installDockerPackages(osInfo *utils.OSInfo) error {
+	if err := installPackageTool("docker", osInfo); err != nil {
+		return fmt.Errorf("erro ao instalar Docker: %w", err)
 	}
+	return nil
+}
 
-	// Atualizar repositórios novamente
-	if err := utils.RunCommand("sudo", "apt-get", "update"); err != nil {
-		return fmt.Errorf("erro ao atualizar repositórios: %w", err)
+// configureDocker adiciona o usuário ao grupo docker, inicia o serviço e
+// verifica se o usuário consegue usar o Docker sem sudo
+func configureDocker(osInfo *utils.OSInfo) error {
+	username, err := invokingUser()
+	if err != nil {
+		return err
 	}
 
-	// Instalar Docker CE
-	dockerPkgs := []string{"docker-ce", "docker-ce-cli", "containerd.io"}
-	for _, pkg := range dockerPkgs {
-		if err := utils.RunCommand("sudo", "apt-get", "install", "-y", pkg); err != nil {
-			return fmt.Errorf("erro ao instalar %s: %w", pkg, err)
+	// root já acessa o socket do Docker (ex: em containers)
+	added := false
+	if username != "root" {
+		if added, err = addUserToDockerGroup(osInfo, username); err != nil {
+			return err
 		}
 	}
 
-	// Adicionar usuário ao grupo docker
-	if err := utils.RunCommand("sudo", "usermod", "-aG", "docker", "$USER"); err != nil {
-		return fmt.Errorf("erro ao adicionar usuário ao grupo docker: %w", err)
+	// Aplicar a configuração do daemon do perfil da equipe
+	restart, err := configureDockerDaemon()
+	if err != nil {
+		return err
 	}
 
 	// Iniciar e habilitar serviço Docker
-	if err := utils.RunCommand("sudo", "systemctl", "start", "docker"); err != nil {
-		return fmt.Errorf("erro ao iniciar serviço Docker: %w", err)
+	if err := enableDockerService(osInfo, restart); err != nil {
+		return err
 	}
 
-	if err := utils.RunCommand("sudo", "systemctl", "enable", "docker"); err != nil {
-		return fmt.Errorf("erro ao habilitar serviço Docker: %w", err)
+	if err := installDockerPlugins(osInfo); err != nil {
+		return err
 	}
 
-	color.Green("✅ Docker instalado com sucesso no Ubuntu!")
-	color.Yellow("⚠️  IMPORTANTE: Faça logout e login novamente para que as permissões do grupo docker sejam aplicadas, ou execute: newgrp docker")
-
+	verifyDockerAccess(username, added)
 	return nil
 }
 
-// installDockerCentOS instala Docker no CentOS/RHEL
-func installDockerCentOS() error {
-	color.Blue("📦 Instalando Docker no CentOS/RHEL...")
+// reconfigureDockerDaemon aplica docker.daemon com o Docker CE já instalado,
+// para que mudanças no perfil da equipe cheguem às máquinas existentes; o
+// daemon só é reiniciado quando daemon.json mudou
+func reconfigureDockerDaemon(osInfo *utils.OSInfo) error {
+	if InstalledDockerProvider() != providerDockerCE {
+		return nil
+	}
 
-	// Instalar yum-utils
-	if err := utils.RunCommand("sudo", "yum", "install", "-y", "yum-utils"); err != nil {
-		return fmt.Errorf("erro ao instalar yum-utils: %w", err)
+	restart, err := configureDockerDaemon()
+	if err != nil || !restart {
+		return err
+	}
+	return enableDockerService(osInfo, true)
+}
+
+// invokingUser retorna o usuário que executou a CLI, mesmo quando ela roda
+// via sudo ou doas (SUDO_USER/DOAS_USER), e não o usuário efetivo
+func invokingUser() (string, error) {
+	for _, env := range []string{"SUDO_USER", "DOAS_USER"} {
+		if name := os.Getenv(env); name != "" && name != "root" {
+			return name, nil
+		}
 	}
 
-	// Adicionar repositório do Docker
-	if err := utils.RunCommand("sudo", "yum-config-manager", "--add-repo", "https://download.docker.com/linux/centos/docker-ce.repo"); err != nil {
-		return fmt.Errorf("erro ao adicionar repositório do Docker: %w", err)
+	current, err := user.Current()
+	if err != nil {
+		return "", fmt.Errorf("erro ao identificar o usuário atual: %w", err)
 	}
+	return current.Username, nil
+}
 
-	// Instalar Docker CE
-	dockerPkgs := []string{"docker-ce", "docker-ce-cli", "containerd.io"}
-	for _, pkg := range dockerPkgs {
-		if err := utils.RunCommand("sudo", "yum", "install", "-y", pkg); err != nil {
-			return fmt.Errorf("erro ao instalar %s: %w", pkg, err)
+// addUserToDockerGroup cria o grupo docker, se ainda não existir, e adiciona
+// o usuário a ele (o Alpine usa o addgroup do busybox). Retorna true se o
+// usuário foi adicionado agora, caso em que a sessão atual ainda não tem o grupo.
+func addUserToDockerGroup(osInfo *utils.OSInfo, username string) (bool, error) {
+	if _, err := user.LookupGroup("docker"); err != nil {
+		groupCmd := []string{"groupadd", "--system", "docker"}
+		if osInfo.Type == utils.Alpine {
+			groupCmd = []string{"addgroup", "-S", "docker"}
+		}
+		if err := privilege.Run(groupCmd[0], groupCmd[1:]...); err != nil {
+			return false, fmt.Errorf("erro ao criar grupo docker: %w", err)
 		}
 	}
 
-	// Iniciar e habilitar serviço Docker
-	if err := utils.RunCommand("sudo", "systemctl", "start", "docker"); err != nil {
-		return fmt.Errorf("erro ao iniciar serviço Docker: %w", err)
+	if inGroup(username, "docker") {
+		return false, nil
 	}
 
-	if err := utils.RunCommand("sudo", "systemctl", "enable", "docker"); err != nil {
-		return fmt.Errorf("erro ao habilitar serviço Docker: %w", err)
+	memberCmd := []string{"usermod", "-aG", "docker", username}
+	if osInfo.Type == utils.Alpine {
+		memberCmd = []string{"addgroup", username, "docker"}
+	}
+	if err := privilege.Run(memberCmd[0], memberCmd[1:]...); err != nil {
+		return false, fmt.Errorf("erro ao adicionar %s ao grupo docker: %w", username, err)
 	}
 
-	// Adicionar usuário ao grupo docker
-	if err := utils.RunCommand("sudo", "usermod", "-aG", "docker", "$USER"); err != nil {
-		return fmt.Errorf("erro ao adicionar usuário ao grupo docker: %w", err)
+	color.Green("✅ Usuário %s adicionado ao grupo docker", username)
+	return true, nil
+}
+
+// inGroup verifica se o usuário pertence ao grupo (como grupo primário ou
+// suplementar) segundo a base de usuários do sistema
+func inGroup(username, group string) bool {
+	u, err := user.Lookup(username)
+	if err != nil {
+		return false
+	}
+	g, err := user.LookupGroup(group)
+	if err != nil {
+		return false
+	}
+	ids, err := u.GroupIds()
+	if err != nil {
+		return false
+	}
+	return contains(ids, g.Gid)
+}
+
+// verifyDockerAccess executa "docker info" como o usuário e explica o que
+// fazer quando o acesso ainda não funciona
+func verifyDockerAccess(username string, justAdded bool) {
+	name, args := "docker", []string{"info"}
+	if current, err := user.Current(); err == nil && current.Username != username {
+		name, args = "su", []string{"-s", "/bin/sh", username, "-c", "docker info"}
+		if !utils.IsRoot() {
+			name, args = privilege.Command(name, args...)
+		}
+	}
+
+	output, err := utils.RunCommandCapture(name, args...)
+	switch {
+	case err == nil:
+		color.Green("✅ Docker acessível por %s sem sudo", username)
+	case strings.Contains(output, "permission denied") && justAdded:
+		color.Yellow("⚠️  IMPORTANTE: a sessão atual de %s ainda não tem o grupo docker. Faça logout e login novamente, ou execute 'newgrp docker' para abrir um shell com o grupo aplicado", username)
+	case strings.Contains(output, "permission denied"):
+		color.Yellow("⚠️  %s não tem permissão no socket do Docker; verifique o grupo com 'id %s' e faça login novamente", username, username)
+	case strings.Contains(output, "Cannot connect") || strings.Contains(output, "daemon running"):
+		color.Yellow("⚠️  O daemon do Docker não está em execução; inicie-o para usar o Docker")
+		if justAdded {
+			color.Yellow("⚠️  Depois, faça logout e login novamente (ou execute 'newgrp docker') para aplicar o grupo docker")
+		}
+	default:
+		color.Yellow("⚠️  Não foi possível verificar o Docker com 'docker info': %v", err)
+	}
+}
+
+// enableDockerService inicia (ou reinicia, quando a configuração do daemon
+// mudou) o serviço do Docker e o habilita no boot com o sistema de init em
+// execução. Sem init (containers, WSL sem systemd) apenas orienta como
+// iniciar o daemon.
+func enableDockerService(osInfo *utils.OSInfo, restart bool) error {
+	initSystem := initsys.Detect()
+	if !initSystem.Managed() {
+		switch {
+		case osInfo.WSL > 0:
+			color.Yellow("⚠️  WSL sem systemd: inicie o Docker com 'sudo service docker start' ou habilite o systemd em /etc/wsl.conf ([boot] systemd=true)")
+		case utils.InContainer():
+			color.Yellow("⚠️  Container sem sistema de init: inicie o daemon com 'dockerd &' ou monte o socket do host (-v /var/run/docker.sock:/var/run/docker.sock)")
+		default:
+			color.Yellow("⚠️  Nenhum sistema de init detectado: inicie o daemon do Docker manualmente (dockerd)")
+		}
+		return nil
 	}
 
-	color.Green("✅ Docker instalado com sucesso no CentOS/RHEL!")
-	color.Yellow("⚠️  IMPORTANTE: Faça logout e login novamente para que as permissões do grupo docker sejam aplicadas, ou execute: newgrp docker")
+	if restart && initSystem.IsActive("docker") {
+		if err := initSystem.Restart("docker"); err != nil {
+			return fmt.Errorf("erro ao reiniciar serviço Docker: %w", err)
+		}
+	} else if err := initSystem.Start("docker"); err != nil {
+		return fmt.Errorf("erro ao iniciar serviço Docker: %w", err)
+	}
+
+	if err := initSystem.Enable("docker"); err != nil {
+		return fmt.Errorf("erro ao habilitar serviço Docker: %w", err)
+	}
 
 	return nil
 }
@@ -0,0 +1,166 @@
+package installer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/fatih/color"
+	"github.com/matheusflausino/setup-devops-cli/internal/config"
+	"github.com/matheusflausino/setup-devops-cli/internal/download"
+	"github.com/matheusflausino/setup-devops-cli/internal/pkgmgr"
+	"github.com/matheusflausino/setup-devops-cli/internal/utils"
+)
+
+// gcloudRelease é o arquivo oficial do Google Cloud CLI, usado nas
+// distribuições sem repositório da Google (Arch, Alpine, openSUSE)
+var gcloudRelease = binaryRelease{
+	Tool:    "gcloud",
+	Version: "496.0.0",
+	URL:     "https://dl.google.com/dl/cloudsdk/channels/rapid/downloads/google-cloud-cli-{version}-linux-{arch}.tar.gz",
+	Arch:    map[string]string{"amd64": "x86_64", "arm64": "arm"},
+}
+
+// gcloudBinaries são os executáveis do arquivo oficial ativados em <prefix>/bin
+var gcloudBinaries = []string{"bin/gcloud", "bin/gsutil", "bin/bq"}
+
+// installGcloud instala o Google Cloud CLI no sistema
+func installGcloud(osInfo *utils.OSInfo) error {
+	if isCommandAvailable("gcloud") {
+		color.Yellow("⚠️  Google Cloud CLI já está instalado")
+		return nil
+	}
+
+	color.Green("☁️  Instalando Google Cloud CLI...")
+
+	switch {
+	case usesPackages("gcloud", osInfo):
+		return installPackageTool("gcloud", osInfo)
+	case osInfo.Type.IsLinux():
+		return installGcloudArchive(osInfo)
+	default:
+		return fmt.Errorf("sistema operacional não suportado para instalação do Google Cloud CLI: %s", osInfo)
+	}
+}
+
+// installGcloudArchive instala o arquivo oficial no layout versionado do
+// prefixo. O gcloud depende do Python 3 do sistema quando o Python embutido
+// no arquivo não pode ser usado (musl no Alpine).
+func installGcloudArchive(osInfo *utils.OSInfo) error {
+	rel := gcloudRelease
+	dir := toolVersionDir(rel.Tool, rel.Version)
+	color.Blue("📦 Instalando Google Cloud CLI %s em %s...", rel.Version, dir)
+
+	if err := installPython(osInfo, "gcloud"); err != nil {
+		return err
+	}
+
+	tmpDir, err := os.MkdirTemp("", "setup-devops-gcloud-")
+	if err != nil {
+		return fmt.Errorf("erro ao criar diretório temporário: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	url := rel.downloadURL("linux", runtime.GOARCH)
+	file := filepath.Join(tmpDir, filepath.Base(url))
+	if err := download.Fetch(rel.Tool, url, file); err != nil {
+		return fmt.Errorf("erro ao baixar Google Cloud CLI: %w", err)
+	}
+
+	if err := runInPrefix("mkdir", "-p", dir, binDir()); err != nil {
+		return fmt.Errorf("erro ao criar diretório %s: %w", dir, err)
+	}
+	if err := runInPrefix("tar", "-xzf", file, "-C", dir, "--strip-components=1"); err != nil {
+		return fmt.Errorf("erro ao extrair Google Cloud CLI: %w", err)
+	}
+
+	// O Python embutido é compilado para glibc
+	if osInfo.Type == utils.Alpine {
+		runInPrefixSilent("rm", "-rf", filepath.Join(dir, "platform", "bundledpythonunix"))
+	}
+
+	if err := activateVersion(rel.Tool, rel.Version, gcloudBinaries...); err != nil {
+		return err
+	}
+
+	if err := installGcloudComponents(osInfo); err != nil {
+		return err
+	}
+
+	color.Green("✅ Google Cloud CLI %s instalado com sucesso em %s!", rel.Version, binDir())
+	return nil
+}
+
+// installGcloudComponents instala os componentes configurados em
+// gcloud.components (ex: gke-gcloud-auth-plugin). Nos repositórios apt/rpm
+// cada componente é um pacote; no arquivo oficial e no Homebrew eles são
+// instalados pelo gerenciador de componentes do próprio gcloud.
+func installGcloudComponents(osInfo *utils.OSInfo) error {
+	components := config.GcloudComponents()
+	if len(components) == 0 {
+		return nil
+	}
+
+	color.Blue("📦 Instalando componentes do gcloud: %v", components)
+
+	if gcloudRepository(osInfo) != nil {
+		pm, err := pkgmgr.ForOS(osInfo)
+		if err != nil {
+			return err
+		}
+
+		pkgs := make([]string, 0, len(components))
+		for _, component := range components {
+			pkgs = append(pkgs, gcloudComponentPackage(component))
+		}
+		return installPackages(pm, []string{"gcloud"}, nil, pkgs)
+	}
+
+	args := append([]string{"components", "install", "--quiet"}, components...)
+	if osInfo.Type == utils.MacOS {
+		if err := utils.RunCommand("gcloud", args...); err != nil {
+			return fmt.Errorf("erro ao instalar componentes do gcloud: %w", err)
+		}
+		return nil
+	}
+
+	if err := runInPrefix(filepath.Join(binDir(), "gcloud"), args...); err != nil {
+		return fmt.Errorf("erro ao instalar componentes do gcloud: %w", err)
+	}
+	return nil
+}
+
+// gcloudComponentPackage retorna o pacote apt/rpm de um componente do gcloud
+func gcloudComponentPackage(component string) string {
+	if component == "kubectl" {
+		return "kubectl"
+	}
+	return "google-cloud-cli-" + component
+}
+
+// installPython instala o Python 3 do sistema, se ainda não estiver disponível
+func installPython(osInfo *utils.OSInfo, tool string) error {
+	if isCommandAvailable("python3") && osInfo.Type != utils.Alpine {
+		return nil
+	}
+
+	pm, err := pkgmgr.ForOS(osInfo)
+	if err != nil {
+		return err
+	}
+
+	pkgs := []string{"python3"}
+	switch pm.Name() {
+	case "pacman":
+		pkgs = []string{"python"}
+	case "apk":
+		// No Alpine o venv/pip fica em um pacote separado
+		pkgs = []string{"python3", "py3-pip"}
+	}
+
+	if err := installPackages(pm, []string{tool}, nil, pkgs); err != nil {
+		return fmt.Errorf("erro ao instalar Python 3: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package installer
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/matheusflausino/setup-devops-cli/internal/config"
+	"github.com/matheusflausino/setup-devops-cli/internal/pkgmgr"
+	"github.com/matheusflausino/setup-devops-cli/internal/utils"
+)
+
+// Releases da HashiCorp: zips em releases.hashicorp.com com um arquivo
+// SHA256SUMS assinado pela chave de segurança da HashiCorp (diferente da
+// chave dos repositórios apt/rpm)
+const (
+	hashicorpReleasesURL        = "https://releases.hashicorp.com"
+	hashicorpReleaseKeyURL      = "https://www.hashicorp.com/.well-known/pgp-key.txt"
+	hashicorpReleaseFingerprint = "C874011F0AB405110D02105534365D9472D7468F"
+)
+
+// hashicorpTools são os produtos da HashiCorp oferecidos pela CLI
+var hashicorpTools = []string{"terraform", "vault", "packer", "consul"}
+
+// hashicorpRelease descreve a release de um produto da HashiCorp
+func hashicorpRelease(product, version string) binaryRelease {
+	base := fmt.Sprintf("%s/%s/{number}/%s_{number}", hashicorpReleasesURL, product, product)
+	return binaryRelease{
+		Tool:         product,
+		Version:      version,
+		URL:          base + "_linux_{arch}.zip",
+		Binary:       product,
+		ArchivePath:  product,
+		Checksums:    base + "_SHA256SUMS",
+		Signature:    base + "_SHA256SUMS.sig",
+		KeyURL:       hashicorpReleaseKeyURL,
+		Fingerprints: []string{hashicorpReleaseFingerprint},
+	}
+}
+
+// hashicorpUsesPackages decide quando o produto vem de pacotes: sempre no
+// macOS (Homebrew) e, no Linux, quando há repositório da HashiCorp (ou o
+// pacote oficial do Terraform no Arch) e nenhuma versão foi fixada em
+// versions.<produto>, já que o repositório não instala versões antigas de
+// forma confiável
+func hashicorpUsesPackages(product string) func(osInfo *utils.OSInfo) bool {
+	return func(osInfo *utils.OSInfo) bool {
+		if isMacOS(osInfo) {
+			return true
+		}
+		packaged := hashicorpRepository(osInfo) != nil || (product == "terraform" && osInfo.Type == utils.Arch)
+		return packaged && config.ToolVersion(product) == ""
+	}
+}
+
+// installHashicorp instala um produto da HashiCorp a partir do repositório
+// oficial (Homebrew no macOS) ou, sem repositório ou com a versão fixada, do
+// zip publicado em releases.hashicorp.com com o SHA256SUMS assinado
+func installHashicorp(product string, osInfo *utils.OSInfo) error {
+	if IsToolInstalled(product) {
+		color.Yellow("⚠️  %s já está instalado", product)
+		return nil
+	}
+
+	color.Green("🏗️  Instalando %s...", product)
+
+	if usesPackages(product, osInfo) {
+		if err := installPackageTool(product, osInfo); err != nil {
+			return fmt.Errorf("erro ao instalar %s: %w", product, err)
+		}
+		return nil
+	}
+
+	if !osInfo.Type.IsLinux() {
+		return fmt.Errorf("sistema operacional não suportado para instalação do %s: %s", product, osInfo)
+	}
+
+	rel := releaseFor(product)
+	if err := installReleaseHelpers(rel, osInfo); err != nil {
+		return err
+	}
+	return installRelease(rel)
+}
+
+// gpgPackage retorna o pacote que fornece o gpg no sistema
+func gpgPackage(osInfo *utils.OSInfo) string {
+	switch osInfo.Type {
+	case utils.CentOS, utils.Fedora, utils.AmazonLinux:
+		return "gnupg2"
+	case utils.OpenSUSE:
+		return "gpg2"
+	default:
+		return "gnupg"
+	}
+}
+
+// installGPG instala o gpg, usado para verificar releases assinadas
+func installGPG(tool string, osInfo *utils.OSInfo) error {
+	pm, err := pkgmgr.ForOS(osInfo)
+	if err != nil {
+		return err
+	}
+	if err := installPackages(pm, []string{tool}, nil, []string{gpgPackage(osInfo)}); err != nil {
+		return fmt.Errorf("erro ao instalar gpg: %w", err)
+	}
+	return nil
+}
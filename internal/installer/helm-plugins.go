@@ -0,0 +1,154 @@
+package installer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/matheusflausino/setup-devops-cli/internal/config"
+	"github.com/matheusflausino/setup-devops-cli/internal/utils"
+)
+
+// knownHelmPlugins mapeia os plugins do Helm mais usados para os seus
+// repositórios, para que o perfil possa informar só o nome
+var knownHelmPlugins = map[string]string{
+	"diff":     "https://github.com/databus23/helm-diff",
+	"secrets":  "https://github.com/jkroepke/helm-secrets",
+	"git":      "https://github.com/aslafy-z/helm-git",
+	"s3":       "https://github.com/hypnoglow/helm-s3",
+	"unittest": "https://github.com/helm-unittest/helm-unittest",
+}
+
+// helmPlugins retorna os plugins do Helm a instalar: os da seção helm.plugins
+// e o helm-diff, exigido pelo Helmfile, quando o Helmfile está instalado
+func helmPlugins() []config.HelmPlugin {
+	plugins := config.HelmPlugins()
+	if !IsToolInstalled("helmfile") {
+		return plugins
+	}
+
+	for _, plugin := range plugins {
+		if plugin.Name == "diff" {
+			return plugins
+		}
+	}
+	return append(plugins, config.HelmPlugin{Name: "diff"})
+}
+
+// installHelmPlugins instala os plugins do Helm ausentes, troca os que estão
+// em uma versão diferente da fixada e atualiza os demais
+func installHelmPlugins() error {
+	plugins := helmPlugins()
+	if len(plugins) == 0 {
+		return nil
+	}
+
+	if !isCommandAvailable("helm") {
+		color.Yellow("⚠️  Helm não está instalado; plugins do Helm não foram instalados")
+		return nil
+	}
+
+	installed := installedHelmPlugins()
+	for _, plugin := range plugins {
+		if err := installHelmPlugin(plugin, installed); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// installHelmPlugin instala ou atualiza um plugin do Helm
+func installHelmPlugin(plugin config.HelmPlugin, installed map[string]string) error {
+	url := plugin.URL
+	if url == "" {
+		url = knownHelmPlugins[plugin.Name]
+	}
+
+	current, ok := installed[plugin.Name]
+	switch {
+	case ok && plugin.Version == "":
+		if output, err := utils.RunCommandCapture("helm", "plugin", "update", plugin.Name); err != nil {
+			return fmt.Errorf("erro ao atualizar o plugin do Helm %s: %s", plugin.Name, utils.LastLines(output))
+		}
+		if version := installedHelmPlugins()[plugin.Name]; version != current {
+			color.Green("✅ Plugin do Helm %s atualizado para %s", plugin.Name, withV(version))
+		}
+		return nil
+	case ok && withV(current) == withV(plugin.Version):
+		return nil
+	case url == "":
+		return fmt.Errorf("plugin do Helm desconhecido: %s (informe a url em helm.plugins)", plugin.Name)
+	case ok:
+		// O "helm plugin update" não aceita versão: reinstalar na versão fixada
+		if output, err := utils.RunCommandCapture("helm", "plugin", "uninstall", plugin.Name); err != nil {
+			return fmt.Errorf("erro ao remover o plugin do Helm %s: %s", plugin.Name, utils.LastLines(output))
+		}
+	}
+
+	args := []string{"plugin", "install", url}
+	if plugin.Version != "" {
+		args = append(args, "--version", plugin.Version)
+	}
+	if output, err := utils.RunCommandCapture("helm", args...); err != nil {
+		return fmt.Errorf("erro ao instalar o plugin do Helm %s: %s", plugin.Name, utils.LastLines(output))
+	}
+
+	if ok {
+		color.Green("✅ Plugin do Helm %s trocado de %s para %s", plugin.Name, withV(current), withV(plugin.Version))
+	} else {
+		color.Green("✅ Plugin do Helm %s instalado", plugin.Name)
+	}
+	return nil
+}
+
+// installedHelmPlugins retorna os plugins do Helm instalados e suas versões
+// segundo "helm plugin list"
+func installedHelmPlugins() map[string]string {
+	plugins := make(map[string]string)
+
+	output, err := utils.RunCommandOutput("helm", "plugin", "list")
+	if err != nil {
+		return plugins
+	}
+
+	// NAME    VERSION  DESCRIPTION
+	// diff    3.9.11   Preview helm upgrade changes as a diff
+	for _, line := range strings.Split(output, "\n")[1:] {
+		if fields := strings.Fields(line); len(fields) >= 2 {
+			plugins[fields[0]] = fields[1]
+		}
+	}
+	return plugins
+}
+
+// helmPluginComponents retorna os plugins do Helm configurados com as
+// versões instaladas
+func helmPluginComponents() []Component {
+	if !isCommandAvailable("helm") {
+		return nil
+	}
+
+	plugins := helmPlugins()
+	installed := installedHelmPlugins()
+
+	components := make([]Component, 0, len(plugins))
+	for _, plugin := range plugins {
+		component := Component{Name: "plugin " + plugin.Name}
+		if version, ok := installed[plugin.Name]; ok {
+			component.Version = withV(version)
+		}
+		if plugin.Version != "" {
+			component.Managed = withV(plugin.Version)
+		}
+		components = append(components, component)
+	}
+	return components
+}
+
+// withV normaliza a versão com o prefixo "v" (o Helm lista 3.9.11)
+func withV(version string) string {
+	if version == "" || strings.HasPrefix(version, "v") {
+		return version
+	}
+	return "v" + version
+}
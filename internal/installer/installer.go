@@ -2,9 +2,12 @@ package installer
 
 import (
 	"fmt"
-	"os/exec"
+	"regexp"
+	"sort"
 
 	"github.com/fatih/color"
+	"github.com/matheusflausino/setup-devops-cli/internal/config"
+	"github.com/matheusflausino/setup-devops-cli/internal/pkgmgr"
 	"github.com/matheusflausino/setup-devops-cli/internal/utils"
 )
 
@@ -22,6 +25,54 @@ var essentialTools = []string{"docker", "git", "net-tools"}
 // Lista de ferramentas Cloud & DevOps
 var cloudDevOpsTools = []string{"terraform", "aws-cli", "kubectl", "watch", "helm", "helmfile", "k9s"}
 
+// CLIs de nuvem opcionais (o AWS CLI faz parte de Cloud & DevOps)
+var optionalCloudTools = []string{"gcloud", "az"}
+
+// Grupo cloud: CLIs dos provedores de nuvem
+var cloudTools = []string{"aws-cli", "gcloud", "az"}
+
+// Grupo local-clusters: ferramentas de clusters Kubernetes locais
+var localClusterTools = []string{"kind", "minikube", "k3d"}
+
+// Grupo kubernetes-extras: ferramentas de produtividade para Kubernetes
+var kubernetesExtrasTools = []string{"kubectx", "kubens", "stern", "kustomize", "krew"}
+
+// Produtos da HashiCorp oferecidos junto ao Terraform (grupo hashicorp,
+// que também inclui o Terraform)
+var hashicorpExtraTools = []string{"vault", "packer", "consul"}
+
+// Grupo iac: ferramentas do ecossistema Terraform
+var iacTools = []string{"terragrunt", "tflint", "trivy", "terraform-docs"}
+
+// Ferramentas opcionais: fazem parte do catálogo, mas só são instaladas
+// quando pedidas explicitamente ou pelos seus grupos
+var optionalTools = concat(optionalCloudTools, localClusterTools, kubernetesExtrasTools, iacTools, hashicorpExtraTools)
+
+// ToolGroup é um grupo de ferramentas aceito por setup --type e --group e
+// oferecido no menu interativo do setup
+type ToolGroup struct {
+	Name        string
+	Description string
+	Tools       []string
+}
+
+// toolGroups lista os grupos na ordem em que aparecem no menu
+var toolGroups = []ToolGroup{
+	{"essentials", "Ferramentas Essenciais (Docker, Git, net-tools)", essentialTools},
+	{"cloud-devops", "Ferramentas Cloud & DevOps (Terraform, AWS CLI, kubectl, etc.)", cloudDevOpsTools},
+	{"cloud", "CLIs de nuvem (AWS CLI, gcloud, az)", cloudTools},
+	{"local-clusters", "Clusters Kubernetes locais (kind, minikube, k3d)", localClusterTools},
+	{"kubernetes-extras", "Extras para Kubernetes (kubectx, kubens, stern, kustomize, krew)", kubernetesExtrasTools},
+	{"iac", "Ecossistema Terraform (terragrunt, tflint, trivy, terraform-docs)", iacTools},
+	{"hashicorp", "Produtos da HashiCorp (Terraform, Vault, Packer, Consul)", hashicorpTools},
+	{"all", "Todas as ferramentas", GetAllTools()},
+}
+
+// GetToolGroups retorna os grupos de ferramentas do catálogo
+func GetToolGroups() []ToolGroup {
+	return toolGroups
+}
+
 // GetAllTools retorna todas as ferramentas disponíveis
 func GetAllTools() []string {
 	return append(essentialTools, cloudDevOpsTools...)
@@ -37,135 +88,424 @@ func GetCloudDevOpsTools() []string {
 	return cloudDevOpsTools
 }
 
+// GetOptionalTools retorna as ferramentas opcionais
+func GetOptionalTools() []string {
+	return optionalTools
+}
+
+// GetOptionalCloudTools retorna as CLIs de nuvem opcionais
+func GetOptionalCloudTools() []string {
+	return optionalCloudTools
+}
+
+// GetLocalClusterTools retorna as ferramentas de clusters locais (grupo local-clusters)
+func GetLocalClusterTools() []string {
+	return localClusterTools
+}
+
+// GetHashicorpTools retorna os produtos opcionais da HashiCorp (vault,
+// packer, consul)
+func GetHashicorpTools() []string {
+	return hashicorpExtraTools
+}
+
+// GetIaCTools retorna as ferramentas do ecossistema Terraform (grupo iac)
+func GetIaCTools() []string {
+	return iacTools
+}
+
+// GetKubernetesExtrasTools retorna as ferramentas de produtividade para
+// Kubernetes (grupo kubernetes-extras)
+func GetKubernetesExtrasTools() []string {
+	return kubernetesExtrasTools
+}
+
+// IsValidTool verifica se a ferramenta faz parte do catálogo
+func IsValidTool(tool string) bool {
+	return contains(GetAllTools(), tool) || contains(optionalTools, tool)
+}
+
+// findToolGroup retorna o grupo com o nome informado
+func findToolGroup(name string) (ToolGroup, bool) {
+	for _, group := range toolGroups {
+		if group.Name == name {
+			return group, true
+		}
+	}
+	return ToolGroup{}, false
+}
+
+// ResolveTools expande grupos (essentials, cloud-devops, cloud,
+// local-clusters, kubernetes-extras, iac, hashicorp, all) e ferramentas
+// em uma lista sem repetições, validando cada nome
+func ResolveTools(tools, groups []string) ([]string, error) {
+	var resolved []string
+	seen := make(map[string]bool)
+
+	add := func(list []string) {
+		for _, tool := range list {
+			if !seen[tool] {
+				seen[tool] = true
+				resolved = append(resolved, tool)
+			}
+		}
+	}
+
+	for _, name := range groups {
+		group, ok := findToolGroup(name)
+		if !ok {
+			return nil, fmt.Errorf("grupo não reconhecido: %s", name)
+		}
+		add(group.Tools)
+	}
+
+	for _, tool := range tools {
+		if !IsValidTool(tool) {
+			return nil, fmt.Errorf("ferramenta não reconhecida: %s", tool)
+		}
+	}
+	add(tools)
+
+	return resolved, nil
+}
+
 // IsToolInstalled verifica se uma ferramenta está instalada
 func IsToolInstalled(tool string) bool {
-	switch tool {
-	case "docker":
-		return isCommandAvailable("docker")
-	case "git":
-		return isCommandAvailable("git")
-	case "terraform":
-		return isCommandAvailable("terraform")
-	case "aws-cli":
-		return isCommandAvailable("aws")
-	case "kubectl":
-		return isCommandAvailable("kubectl")
-	case "watch":
-		return isCommandAvailable("watch")
-	case "helm":
-		return isCommandAvailable("helm")
-	case "helmfile":
-		return isCommandAvailable("helmfile")
-	case "net-tools":
-		return isCommandAvailable("netstat") || isCommandAvailable("ifconfig") || isCommandAvailable("route")
-	case "k9s":
-		return isCommandAvailable("k9s")
-	default:
-		return false
+	if tool == "docker" {
+		return InstalledDockerProvider() != ""
 	}
+
+	for _, command := range toolCommands[tool] {
+		if isCommandAvailable(command) {
+			return true
+		}
+	}
+	return false
+}
+
+// toolCommands lista os comandos que cada ferramenta fornece
+var toolCommands = map[string][]string{
+	"docker":         {"docker"},
+	"git":            {"git"},
+	"terraform":      {"terraform"},
+	"aws-cli":        {"aws"},
+	"kubectl":        {"kubectl"},
+	"watch":          {"watch"},
+	"helm":           {"helm"},
+	"helmfile":       {"helmfile"},
+	"net-tools":      {"netstat", "ifconfig", "route"},
+	"k9s":            {"k9s"},
+	"gcloud":         {"gcloud"},
+	"az":             {"az"},
+	"kind":           {"kind"},
+	"minikube":       {"minikube"},
+	"k3d":            {"k3d"},
+	"kubectx":        {"kubectx"},
+	"kubens":         {"kubens"},
+	"stern":          {"stern"},
+	"kustomize":      {"kustomize"},
+	"krew":           {"kubectl-krew"},
+	"terragrunt":     {"terragrunt"},
+	"tflint":         {"tflint"},
+	"trivy":          {"trivy"},
+	"terraform-docs": {"terraform-docs"},
+	"vault":          {"vault"},
+	"packer":         {"packer"},
+	"consul":         {"consul"},
+}
+
+// versionCommands são os comandos que informam a versão de cada ferramenta
+var versionCommands = map[string][]string{
+	"terraform":      {"terraform", "version"},
+	"kubectl":        {"kubectl", "version", "--client"},
+	"helm":           {"helm", "version", "--short"},
+	"aws-cli":        {"aws", "--version"},
+	"gcloud":         {"gcloud", "version"},
+	"az":             {"az", "version"},
+	"kind":           {"kind", "version"},
+	"minikube":       {"minikube", "version", "--short"},
+	"k3d":            {"k3d", "version"},
+	"kubectx":        {"kubectx", "--version"},
+	"kubens":         {"kubens", "--version"},
+	"stern":          {"stern", "--version"},
+	"kustomize":      {"kustomize", "version"},
+	"krew":           {"kubectl-krew", "version"},
+	"terragrunt":     {"terragrunt", "--version"},
+	"tflint":         {"tflint", "--version"},
+	"trivy":          {"trivy", "--version"},
+	"terraform-docs": {"terraform-docs", "--version"},
+	"vault":          {"vault", "version"},
+	"packer":         {"packer", "version"},
+	"consul":         {"consul", "version"},
 }
 
-// isCommandAvailable verifica se um comando está disponível no PATH
+// versionPattern encontra o primeiro número de versão na saída de um comando
+var versionPattern = regexp.MustCompile(`v?\d+\.\d+(\.\d+)?`)
+
+// ToolVersion retorna a versão instalada da ferramenta, ou "" quando ela não
+// está instalada ou não informa a versão
+func ToolVersion(tool string) string {
+	command, ok := versionCommands[tool]
+	if !ok || !isCommandAvailable(command[0]) {
+		return ""
+	}
+
+	output, err := utils.RunCommandOutput(command[0], command[1:]...)
+	if err != nil {
+		return ""
+	}
+	return versionPattern.FindString(output)
+}
+
+// WindowsShadowedTools retorna, no WSL, as ferramentas cujo comando é
+// resolvido pelo PATH para um executável do Windows (ex: /mnt/c/...), que
+// tem precedência sobre a versão do Linux ou é usado no lugar dela
+func WindowsShadowedTools() map[string]string {
+	shadowed := make(map[string]string)
+	if utils.WSLVersion() == 0 {
+		return shadowed
+	}
+
+	for tool, commands := range toolCommands {
+		for _, command := range commands {
+			if path, ok := utils.WindowsShadow(command); ok {
+				shadowed[tool] = path
+				break
+			}
+		}
+	}
+	return shadowed
+}
+
+// isCommandAvailable verifica se um comando está disponível no PATH (no WSL,
+// executáveis do Windows não contam)
 func isCommandAvailable(command string) bool {
-	_, err := exec.LookPath(command)
+	_, err := utils.LookPath(command)
 	return err == nil
 }
 
 // CheckPrerequisites verifica os pré-requisitos do sistema
-func CheckPrerequisites(osType utils.OSType) error {
+func CheckPrerequisites(osInfo *utils.OSInfo) error {
 	color.Blue("🔍 Verificando pré-requisitos...")
 
-	switch osType {
-	case utils.MacOS:
+	// Verificar a versão mínima suportada do sistema
+	if err := osInfo.CheckMinimumVersion(); err != nil {
+		if !config.Force() {
+			return fmt.Errorf("%w; use --force para continuar mesmo assim", err)
+		}
+		color.Yellow("⚠️  %v; continuando por causa de --force", err)
+	}
+
+	switch {
+	case osInfo.Type == utils.MacOS:
 		if !isCommandAvailable("brew") {
 			return fmt.Errorf("Homebrew não está instalado. Instale primeiro: https://brew.sh")
 		}
 		color.Green("✅ Homebrew encontrado")
-	case utils.Ubuntu, utils.CentOS:
+	case osInfo.Type.IsLinux():
 		// Verificar se curl está disponível
 		if !isCommandAvailable("curl") {
-			return fmt.Errorf("curl não está instalado. Instale primeiro: sudo apt-get install curl (Ubuntu) ou sudo yum install curl (CentOS)")
+			return fmt.Errorf("curl não está instalado. Instale primeiro o pacote curl com %s", pkgmgr.NameFor(osInfo))
 		}
 		color.Green("✅ curl encontrado")
 	}
 
+	if osInfo.WSL > 0 {
+		color.Blue("🪟 WSL%d detectado (%s)", osInfo.WSL, utils.WSLDistro())
+		WarnWindowsShadowedTools()
+	}
+
 	return nil
 }
 
-// InstallEssentials instala as ferramentas essenciais
-func InstallEssentials(osType utils.OSType) error {
-	color.Green("📦 Instalando ferramentas essenciais...")
+// WarnWindowsShadowedTools avisa sobre executáveis do Windows no PATH que
+// tomam o lugar das ferramentas do Linux
+func WarnWindowsShadowedTools() {
+	shadowed := WindowsShadowedTools()
+	tools := make([]string, 0, len(shadowed))
+	for tool := range shadowed {
+		tools = append(tools, tool)
+	}
+	sort.Strings(tools)
 
-	for _, tool := range essentialTools {
-		if err := InstallTool(tool, osType); err != nil {
-			color.Red("❌ Erro ao instalar %s: %v", tool, err)
-			// Continue com as outras ferramentas
-		}
+	for _, tool := range tools {
+		color.Yellow("⚠️  %s resolve para o executável do Windows %s; instale a versão do Linux e mantenha-a antes de /mnt/c no PATH (ou defina appendWindowsPath=false em /etc/wsl.conf)", tool, shadowed[tool])
 	}
+}
 
+// InstallEssentials instala as ferramentas essenciais
+func InstallEssentials(osInfo *utils.OSInfo) error {
+	color.Green("📦 Instalando ferramentas essenciais...")
+
+	// Continue com as outras ferramentas em caso de erro
+	installToolList(essentialTools, osInfo)
 	return nil
 }
 
 // InstallCloudDevOps instala as ferramentas Cloud & DevOps
-func InstallCloudDevOps(osType utils.OSType) error {
+func InstallCloudDevOps(osInfo *utils.OSInfo) error {
 	color.Green("☁️  Instalando ferramentas Cloud & DevOps...")
 
-	for _, tool := range cloudDevOpsTools {
-		if err := InstallTool(tool, osType); err != nil {
-			color.Red("❌ Erro ao instalar %s: %v", tool, err)
-			// Continue com as outras ferramentas
-		}
-	}
-
+	// Continue com as outras ferramentas em caso de erro
+	installToolList(cloudDevOpsTools, osInfo)
 	return nil
 }
 
 // InstallAll instala todas as ferramentas
-func InstallAll(osType utils.OSType) error {
+func InstallAll(osInfo *utils.OSInfo) error {
 	color.Green("🔧 Instalando todas as ferramentas...")
 
-	allTools := GetAllTools()
-	for i, tool := range allTools {
-		utils.ShowProgress(i+1, len(allTools), fmt.Sprintf("Instalando %s", tool))
-		if err := InstallTool(tool, osType); err != nil {
+	// Continue com as outras ferramentas em caso de erro
+	installToolList(GetAllTools(), osInfo)
+	return nil
+}
+
+// InstallTools instala as ferramentas informadas, continuando em caso de erro
+func InstallTools(tools []string, osInfo *utils.OSInfo) error {
+	if failed := installToolList(tools, osInfo); failed > 0 {
+		return fmt.Errorf("%d de %d ferramentas não foram instaladas", failed, len(tools))
+	}
+	return nil
+}
+
+// installToolList instala as ferramentas e retorna quantas falharam. As que
+// usam o gerenciador de pacotes são instaladas antes, em uma única transação;
+// se ela falhar, cada uma é instalada individualmente para isolar o erro.
+func installToolList(tools []string, osInfo *utils.OSInfo) int {
+	if err := preparePrivileges(tools, osInfo); err != nil {
+		color.Red("❌ %v", err)
+		return len(tools)
+	}
+
+	var batch []string
+	for _, tool := range tools {
+		if !IsToolInstalled(tool) && usesPackages(tool, osInfo) && batchable(tool, osInfo) {
+			batch = append(batch, tool)
+		}
+	}
+
+	failed := 0
+	remaining := tools
+	if len(batch) > 1 {
+		if err := installToolPackages(batch, osInfo); err != nil {
+			color.Yellow("⚠️  Instalação em lote falhou (%v); instalando uma a uma", err)
+		} else {
+			for _, tool := range batch {
+				if err := postInstallTool(tool, osInfo); err != nil {
+					color.Red("❌ Erro ao configurar %s: %v", tool, err)
+					failed++
+				} else if err := installComponents(tool, osInfo); err != nil {
+					color.Red("❌ Erro ao instalar os plugins de %s: %v", tool, err)
+					failed++
+				}
+			}
+
+			remaining = nil
+			for _, tool := range tools {
+				if !contains(batch, tool) {
+					remaining = append(remaining, tool)
+				}
+			}
+		}
+	}
+
+	for i, tool := range remaining {
+		utils.ShowProgress(i+1, len(remaining), fmt.Sprintf("Instalando %s", tool))
+		if err := installTool(tool, osInfo); err != nil {
 			color.Red("❌ Erro ao instalar %s: %v", tool, err)
-			// Continue com as outras ferramentas
+			failed++
 		}
 	}
+	return failed
+}
 
-	return nil
+// batchable verifica se a ferramenta pode entrar na transação em lote. O
+// Docker só entra quando o provedor é válido e deve mesmo ser instalado; nos
+// demais casos installDocker explica o motivo ao instalá-lo individualmente.
+func batchable(tool string, osInfo *utils.OSInfo) bool {
+	if tool != "docker" {
+		return true
+	}
+	_, skip, err := checkDockerInstall(osInfo)
+	return err == nil && skip == ""
+}
+
+// concat junta as listas em uma nova lista
+func concat(lists ...[]string) []string {
+	var result []string
+	for _, list := range lists {
+		result = append(result, list...)
+	}
+	return result
+}
+
+// contains verifica se a lista contém o item
+func contains(list []string, item string) bool {
+	for _, v := range list {
+		if v == item {
+			return true
+		}
+	}
+	return false
 }
 
 // InstallTool instala uma ferramenta específica
-func InstallTool(tool string, osType utils.OSType) error {
+func InstallTool(tool string, osInfo *utils.OSInfo) error {
+	if err := preparePrivileges([]string{tool}, osInfo); err != nil {
+		return err
+	}
+	return installTool(tool, osInfo)
+}
+
+// installTool instala a ferramenta sem listar os comandos privilegiados,
+// já listados por quem instala várias ferramentas de uma vez
+func installTool(tool string, osInfo *utils.OSInfo) error {
 	if IsToolInstalled(tool) {
 		color.Yellow("⚠️  %s já está instalado", tool)
-		return nil
+		// Os plugins são instalados e atualizados mesmo com a ferramenta já instalada
+		return installComponents(tool, osInfo)
 	}
 
 	color.Green("🔧 Instalando %s...", tool)
 
+	if err := installSingleTool(tool, osInfo); err != nil {
+		return err
+	}
+	return installComponents(tool, osInfo)
+}
+
+// installSingleTool executa o instalador específico da ferramenta
+func installSingleTool(tool string, osInfo *utils.OSInfo) error {
 	switch tool {
 	case "docker":
-		return installDocker(osType)
+		return installDocker(osInfo)
 	case "git":
-		return installGit(osType)
-	case "terraform":
-		return installTerraform(osType)
+		return installGit(osInfo)
+	case "terraform", "vault", "packer", "consul":
+		return installHashicorp(tool, osInfo)
 	case "aws-cli":
-		return installAWSCLI(osType)
+		return installAWSCLI(osInfo)
 	case "kubectl":
-		return installKubectl(osType)
+		return installKubectl(osInfo)
 	case "watch":
-		return installWatch(osType)
+		return installWatch(osInfo)
 	case "helm":
-		return installHelm(osType)
+		return installHelm(osInfo)
 	case "helmfile":
-		return installHelmfile(osType)
+		return installHelmfile(osInfo)
 	case "net-tools":
-		return installNetTools(osType)
+		return installNetTools(osInfo)
 	case "k9s":
-		return installK9s(osType)
+		return installK9s(osInfo)
+	case "gcloud":
+		return installGcloud(osInfo)
+	case "az":
+		return installAz(osInfo)
+	case "kind", "minikube", "k3d", "kubectx", "kubens", "stern", "kustomize",
+		"terragrunt", "tflint", "trivy", "terraform-docs":
+		return installBinaryTool(tool, osInfo)
+	case "krew":
+		return installKrew(osInfo)
 	default:
 		return fmt.Errorf("ferramenta não reconhecida: %s", tool)
 	}
@@ -0,0 +1,125 @@
+package installer
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/matheusflausino/setup-devops-cli/internal/config"
+	"github.com/matheusflausino/setup-devops-cli/internal/utils"
+)
+
+// krewCommand é o executável do krew, chamado diretamente para não depender
+// do kubectl (kubectl krew)
+const krewCommand = "kubectl-krew"
+
+// krewRoot retorna o diretório do krew (KREW_ROOT ou ~/.krew)
+func krewRoot() string {
+	if root := os.Getenv("KREW_ROOT"); root != "" {
+		return root
+	}
+	return config.ExpandHome("~/.krew")
+}
+
+// installKrewPlugins instala os plugins de kubectl.krewPlugins que faltam e
+// atualiza os demais, instalando antes o próprio krew quando necessário
+func installKrewPlugins(osInfo *utils.OSInfo) error {
+	plugins := config.KrewPlugins()
+	if len(plugins) == 0 {
+		return nil
+	}
+
+	if !IsToolInstalled("krew") {
+		if err := installKrew(osInfo); err != nil {
+			return err
+		}
+	}
+
+	// O índice de plugins do krew é um repositório git
+	if !isCommandAvailable("git") {
+		return fmt.Errorf("o krew precisa do git para baixar o índice de plugins; instale-o com 'setup-devops install git'")
+	}
+
+	if output, err := utils.RunCommandCapture(krewCommand, "update"); err != nil {
+		return fmt.Errorf("erro ao atualizar o índice do krew: %s", utils.LastLines(output))
+	}
+
+	var missing, installed []string
+	for _, plugin := range plugins {
+		if krewPluginVersion(plugin) == "" {
+			missing = append(missing, plugin)
+		} else {
+			installed = append(installed, plugin)
+		}
+	}
+
+	if len(missing) > 0 {
+		args := append([]string{"install", "--no-update-index"}, missing...)
+		if output, err := utils.RunCommandCapture(krewCommand, args...); err != nil {
+			return fmt.Errorf("erro ao instalar plugins do krew: %s", utils.LastLines(output))
+		}
+		color.Green("✅ Plugins do krew instalados: %s", strings.Join(missing, ", "))
+	}
+
+	if len(installed) > 0 {
+		args := append([]string{"upgrade", "--no-update-index"}, installed...)
+		if output, err := utils.RunCommandCapture(krewCommand, args...); err != nil {
+			return fmt.Errorf("erro ao atualizar plugins do krew: %s", utils.LastLines(output))
+		}
+	}
+
+	warnKrewPath()
+	return nil
+}
+
+// warnKrewPath avisa quando o diretório de plugins do krew não está no PATH,
+// onde o kubectl procura os plugins
+func warnKrewPath() {
+	bin := filepath.Join(krewRoot(), "bin")
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		if filepath.Clean(dir) == bin {
+			return
+		}
+	}
+	color.Yellow("⚠️  Adicione os plugins do krew ao PATH: export PATH=\"${KREW_ROOT:-$HOME/.krew}/bin:$PATH\"")
+}
+
+// krewPluginVersion retorna a versão instalada do plugin segundo o recibo
+// do krew (<krew>/receipts/<plugin>.yaml), ou "" se não estiver instalado
+func krewPluginVersion(plugin string) string {
+	// Plugins de índices adicionais são informados como <índice>/<plugin>
+	file, err := os.Open(filepath.Join(krewRoot(), "receipts", path.Base(plugin)+".yaml"))
+	if err != nil {
+		return ""
+	}
+	defer file.Close()
+
+	// spec:
+	//   version: v0.9.5
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if version, ok := strings.CutPrefix(strings.TrimSpace(scanner.Text()), "version:"); ok {
+			return strings.Trim(strings.TrimSpace(version), `"`)
+		}
+	}
+	return "?"
+}
+
+// krewPluginComponents retorna os plugins de kubectl.krewPlugins com as
+// versões instaladas
+func krewPluginComponents() []Component {
+	plugins := config.KrewPlugins()
+
+	components := make([]Component, 0, len(plugins))
+	for _, plugin := range plugins {
+		components = append(components, Component{
+			Name:    "plugin " + plugin,
+			Version: krewPluginVersion(plugin),
+		})
+	}
+	return components
+}
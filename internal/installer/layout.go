@@ -0,0 +1,138 @@
+package installer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/matheusflausino/setup-devops-cli/internal/config"
+	"github.com/matheusflausino/setup-devops-cli/internal/privilege"
+	"github.com/matheusflausino/setup-devops-cli/internal/utils"
+)
+
+// Layout de instalação dentro do prefixo:
+//
+//	<prefix>/opt/<tool>/<version>/<binary>  - binários de cada versão
+//	<prefix>/bin/<binary>                   - symlink para a versão ativa
+//
+// Várias versões podem coexistir e a troca da versão ativa é feita
+// substituindo o symlink com um rename, que é atômico.
+
+// binDir retorna o diretório de executáveis do prefixo
+func binDir() string {
+	return filepath.Join(config.Prefix(), "bin")
+}
+
+// toolDir retorna o diretório com todas as versões instaladas de uma ferramenta
+func toolDir(tool string) string {
+	return filepath.Join(config.Prefix(), "opt", tool)
+}
+
+// toolVersionDir retorna o diretório de uma versão específica de uma ferramenta
+func toolVersionDir(tool, version string) string {
+	return filepath.Join(toolDir(tool), version)
+}
+
+// installVersionedBinaryIn copia o executável src para o diretório da versão
+// e ativa essa versão em linkDir (ex: diretório de plugins da CLI do Docker)
+func installVersionedBinaryIn(linkDir, tool, version, src, binary string) error {
+	if err := runInPrefix("mkdir", "-p", linkDir); err != nil {
+		return fmt.Errorf("erro ao criar diretório %s: %w", linkDir, err)
+	}
+
+	if err := copyVersionedBinary(tool, version, src, binary); err != nil {
+		return err
+	}
+
+	return activateVersionIn(linkDir, tool, version, binary)
+}
+
+// copyVersionedBinary copia o executável src para o diretório da versão,
+// sem ativá-la (ex: versões fixadas por projeto, usadas pelos shims)
+func copyVersionedBinary(tool, version, src, binary string) error {
+	dir := toolVersionDir(tool, version)
+
+	if err := runInPrefix("mkdir", "-p", dir); err != nil {
+		return fmt.Errorf("erro ao criar diretório %s: %w", dir, err)
+	}
+
+	if err := runInPrefix("install", "-m", "0755", src, filepath.Join(dir, binary)); err != nil {
+		return fmt.Errorf("erro ao copiar %s para %s: %w", binary, dir, err)
+	}
+	return nil
+}
+
+// activateVersion aponta <prefix>/bin/<binary> para a versão informada.
+// O symlink é criado com nome temporário e depois renomeado sobre o atual,
+// de forma que nunca existe um momento sem o executável disponível.
+func activateVersion(tool, version string, binaries ...string) error {
+	return activateVersionIn(binDir(), tool, version, binaries...)
+}
+
+// activateVersionIn aponta linkDir/<binary> para a versão informada. O
+// binário pode estar em um subdiretório da versão (ex: bin/gcloud); o link
+// usa apenas o nome do arquivo.
+func activateVersionIn(linkDir, tool, version string, binaries ...string) error {
+	dir := toolVersionDir(tool, version)
+
+	for _, binary := range binaries {
+		target := filepath.Join(dir, binary)
+		if _, err := os.Stat(target); err != nil {
+			return fmt.Errorf("%s %s não está instalado em %s", tool, version, dir)
+		}
+
+		link := filepath.Join(linkDir, filepath.Base(binary))
+		tmpLink := filepath.Join(linkDir, "."+filepath.Base(binary)+".tmp")
+
+		runInPrefixSilent("rm", "-f", tmpLink)
+		if err := runInPrefix("ln", "-s", target, tmpLink); err != nil {
+			return fmt.Errorf("erro ao criar link para %s: %w", binary, err)
+		}
+
+		if err := runInPrefix("mv", "-f", tmpLink, link); err != nil {
+			runInPrefixSilent("rm", "-f", tmpLink)
+			return fmt.Errorf("erro ao ativar %s %s: %w", tool, version, err)
+		}
+	}
+
+	return nil
+}
+
+// runInPrefix executa um comando que altera o prefixo, elevando privilégios
+// apenas quando o prefixo não é gravável pelo usuário atual
+func runInPrefix(name string, args ...string) error {
+	if isWritable(config.Prefix()) {
+		return utils.RunCommand(name, args...)
+	}
+	return privilege.Run(name, args...)
+}
+
+// runInPrefixSilent executa runInPrefix ignorando erros (para operações de limpeza)
+func runInPrefixSilent(name string, args ...string) {
+	_ = runInPrefix(name, args...)
+}
+
+// isWritable verifica se o usuário atual pode escrever no diretório informado
+// ou, caso ele ainda não exista, no primeiro diretório ancestral existente
+func isWritable(dir string) bool {
+	for {
+		if info, err := os.Stat(dir); err == nil {
+			if !info.IsDir() {
+				return false
+			}
+			f, err := os.CreateTemp(dir, ".setup-devops-*")
+			if err != nil {
+				return false
+			}
+			_ = f.Close()
+			_ = os.Remove(f.Name())
+			return true
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return false
+		}
+		dir = parent
+	}
+}
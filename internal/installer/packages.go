@@ -0,0 +1,506 @@
+package installer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/matheusflausino/setup-devops-cli/internal/bundle"
+	"github.com/matheusflausino/setup-devops-cli/internal/pkgmgr"
+	"github.com/matheusflausino/setup-devops-cli/internal/utils"
+)
+
+// packageSpec descreve como uma ferramenta é instalada pelo gerenciador de
+// pacotes do sistema
+type packageSpec struct {
+	// Packages são os pacotes instalados por padrão, em qualquer gerenciador
+	Packages []string
+	// Overrides substitui Packages para um sistema (utils.OSType, ex: alpine)
+	// ou um gerenciador (apt, dnf, brew...); o sistema tem precedência
+	Overrides map[string][]string
+	// Repository retorna o repositório de terceiros que fornece os pacotes no
+	// sistema informado, ou nil quando os repositórios da distribuição bastam
+	Repository func(osInfo *utils.OSInfo) *pkgmgr.Repository
+	// When limita os sistemas em que a ferramenta é instalada por pacotes
+	// (nil para todos); nos demais ela usa o instalador próprio
+	When func(osInfo *utils.OSInfo) bool
+	// PostInstall configura a ferramenta depois que os pacotes são instalados
+	PostInstall func(osInfo *utils.OSInfo) error
+}
+
+// appliesTo verifica se a ferramenta é instalada por pacotes no sistema
+func (s packageSpec) appliesTo(osInfo *utils.OSInfo) bool {
+	return s.When == nil || s.When(osInfo)
+}
+
+// For retorna os pacotes a instalar no sistema com o gerenciador informado
+func (s packageSpec) For(osType utils.OSType, pm string) []string {
+	if pkgs, ok := s.Overrides[string(osType)]; ok {
+		return pkgs
+	}
+	if pkgs, ok := s.Overrides[pm]; ok {
+		return pkgs
+	}
+	return s.Packages
+}
+
+// Pacotes do sistema que instalam cada ferramenta. As ferramentas distribuídas
+// como binários (kubectl, helm, ...) só usam pacotes no macOS (Homebrew).
+var toolPackages = map[string]packageSpec{
+	"docker": {
+		Packages: []string{"docker-ce", "docker-ce-cli", "containerd.io"},
+		// Sem repositório oficial do Docker: pacotes da própria distribuição
+		Overrides: map[string][]string{
+			string(utils.AmazonLinux): {"docker"},
+			"zypper":                  {"docker"},
+			"pacman":                  {"docker"},
+			"apk":                     {"docker"},
+		},
+		Repository: dockerRepository,
+		// No macOS o Docker Desktop é instalado como cask
+		When:        isLinux,
+		PostInstall: configureDocker,
+	},
+	// Provedores alternativos do Docker (ver docker-provider.go)
+	"podman": {
+		// podman-docker fornece o comando docker como atalho para o podman
+		Packages:    []string{"podman", "podman-docker"},
+		When:        isLinux,
+		PostInstall: configurePodman,
+	},
+	"colima": {
+		// O Colima roda o daemon em uma VM; o comando docker e os plugins
+		// compose e buildx são os do Homebrew
+		Packages:    []string{"colima", "docker", "docker-compose", "docker-buildx"},
+		When:        isMacOS,
+		PostInstall: configureColima,
+	},
+	"git": {
+		Packages: []string{"git"},
+	},
+	"net-tools": {
+		Packages: []string{"net-tools"},
+	},
+	"watch": {
+		Packages: []string{"procps"},
+		Overrides: map[string][]string{
+			"dnf":    {"procps-ng"},
+			"yum":    {"procps-ng"},
+			"pacman": {"procps-ng"},
+			"brew":   {"watch"},
+		},
+	},
+	// Produtos da HashiCorp: openSUSE e Alpine não têm repositório da
+	// HashiCorp e usam releases.hashicorp.com, assim como as versões fixadas
+	"terraform": {
+		Packages:   []string{"terraform"},
+		Repository: hashicorpRepository,
+		When:       hashicorpUsesPackages("terraform"),
+	},
+	"vault": {
+		Packages:   []string{"vault"},
+		Overrides:  map[string][]string{"brew": {"hashicorp/tap/vault"}},
+		Repository: hashicorpRepository,
+		When:       hashicorpUsesPackages("vault"),
+	},
+	"packer": {
+		Packages:   []string{"packer"},
+		Overrides:  map[string][]string{"brew": {"hashicorp/tap/packer"}},
+		Repository: hashicorpRepository,
+		When:       hashicorpUsesPackages("packer"),
+	},
+	"consul": {
+		Packages:   []string{"consul"},
+		Overrides:  map[string][]string{"brew": {"hashicorp/tap/consul"}},
+		Repository: hashicorpRepository,
+		When:       hashicorpUsesPackages("consul"),
+	},
+	"aws-cli": {
+		Packages:  []string{"awscli"},
+		Overrides: map[string][]string{"apk": {"aws-cli"}},
+		// O instalador oficial depende da glibc; no Alpine (musl) usar o pacote da distribuição
+		When: func(osInfo *utils.OSInfo) bool {
+			return osInfo.Type == utils.MacOS || osInfo.Type == utils.Alpine
+		},
+	},
+	"gcloud": {
+		Packages: []string{"google-cloud-cli"},
+		Overrides: map[string][]string{
+			"brew": {"google-cloud-sdk"},
+		},
+		Repository: gcloudRepository,
+		// Nas demais distribuições usa o arquivo oficial (ver gcloud.go)
+		When: func(osInfo *utils.OSInfo) bool {
+			return gcloudRepository(osInfo) != nil || osInfo.Type == utils.MacOS
+		},
+		PostInstall: installGcloudComponents,
+	},
+	"az": {
+		Packages:   []string{"azure-cli"},
+		Repository: azureRepository,
+		// Sem pacote no Alpine: instalado com pip em um virtualenv (ver az.go)
+		When: func(osInfo *utils.OSInfo) bool {
+			return osInfo.Type != utils.Alpine
+		},
+	},
+	"kubectl":  {Packages: []string{"kubectl"}, When: isMacOS},
+	"helm":     {Packages: []string{"helm"}, When: isMacOS},
+	"helmfile": {Packages: []string{"helmfile"}, When: isMacOS},
+	"k9s":      {Packages: []string{"k9s"}, When: isMacOS},
+	"kind":     {Packages: []string{"kind"}, When: isMacOS},
+	"minikube": {Packages: []string{"minikube"}, When: isMacOS},
+	"k3d":      {Packages: []string{"k3d"}, When: isMacOS},
+	// A fórmula kubectx do Homebrew também instala o kubens
+	"kubectx":   {Packages: []string{"kubectx"}, When: isMacOS},
+	"kubens":    {Packages: []string{"kubectx"}, When: isMacOS},
+	"stern":     {Packages: []string{"stern"}, When: isMacOS},
+	"kustomize": {Packages: []string{"kustomize"}, When: isMacOS},
+	"krew":      {Packages: []string{"krew"}, When: isMacOS},
+	// Grupo iac
+	"terragrunt":     {Packages: []string{"terragrunt"}, When: isMacOS},
+	"tflint":         {Packages: []string{"tflint"}, When: isMacOS},
+	"trivy":          {Packages: []string{"trivy"}, When: isMacOS},
+	"terraform-docs": {Packages: []string{"terraform-docs"}, When: isMacOS},
+}
+
+// isLinux restringe packageSpec.When às distribuições Linux
+func isLinux(osInfo *utils.OSInfo) bool {
+	return osInfo.Type.IsLinux()
+}
+
+// isMacOS restringe packageSpec.When ao macOS (Homebrew)
+func isMacOS(osInfo *utils.OSInfo) bool {
+	return osInfo.Type == utils.MacOS
+}
+
+// Pacotes auxiliares exigidos pelos instaladores que não usam o gerenciador
+// de pacotes para a ferramenta em si
+var helperPackages = map[string][]string{
+	// unzip é necessário para extrair os instaladores oficiais
+	"aws-cli":   {"unzip"},
+	"terraform": {"unzip"},
+	"vault":     {"unzip"},
+	"packer":    {"unzip"},
+	"consul":    {"unzip"},
+	"tflint":    {"unzip"},
+}
+
+// Fingerprints das chaves que assinam os repositórios oficiais. A chave
+// baixada é rejeitada se não conferir, mesmo quando vem de um mirror.
+const (
+	dockerAptFingerprint = "9DC858229FC7DD38854AE2D88D81803C0EBFCD88"
+	dockerRPMFingerprint = "060A61C51B558A7F742B77AAC52FEB6B621E9F35"
+	hashicorpFingerprint = "798AEC654E5C15428C8E42EEAA16FCBCA621E701"
+	microsoftFingerprint = "BC528686B50D79E339D3721CEB3E94ADBE1229CF"
+)
+
+// googleCloudFingerprints são as chaves publicadas pela Google nos arquivos de
+// chave dos repositórios do Google Cloud CLI. A Google acrescenta a nova chave
+// ao mesmo arquivo a cada rotação, e todas precisam estar fixadas aqui.
+var googleCloudFingerprints = []string{
+	"54A647F9048D5688D7DA2ABE6A030B21BA07F4FB", // Google Cloud Packages Automatic Signing Key
+	"59FE0256827269DC81578F928B57C5C2836F4BEB", // Rapture Automatic Signing Key (2020)
+	"7F92E05B31093BEF5A3C2D38FEEA9169307EA071", // Rapture Automatic Signing Key (2021)
+	"A362B822F6DEDC652817EA46B53DC80D13EDEF05", // Rapture Automatic Signing Key (2022)
+	"35BAA0B33E9EB396F59CA838C0BA5CE6DC6315A3", // Artifact Registry Repository Signer
+}
+
+// dockerRepository retorna o repositório oficial do Docker. Amazon Linux,
+// openSUSE, Arch e Alpine não têm repositório oficial e usam os pacotes da
+// própria distribuição.
+func dockerRepository(osInfo *utils.OSInfo) *pkgmgr.Repository {
+	switch osInfo.Type {
+	case utils.Ubuntu, utils.Debian:
+		return &pkgmgr.Repository{
+			Name:         "docker",
+			Tool:         "docker",
+			URL:          "https://download.docker.com/linux/" + string(osInfo.Type),
+			KeyURL:       "https://download.docker.com/linux/" + string(osInfo.Type) + "/gpg",
+			Fingerprints: []string{dockerAptFingerprint},
+			Suite:        osInfo.Codename,
+			Components:   []string{"stable"},
+		}
+	case utils.CentOS, utils.Fedora:
+		return &pkgmgr.Repository{
+			Name:         "docker-ce",
+			Tool:         "docker",
+			KeyURL:       "https://download.docker.com/linux/" + string(osInfo.Type) + "/gpg",
+			Fingerprints: []string{dockerRPMFingerprint},
+			RepoFileURL:  "https://download.docker.com/linux/" + string(osInfo.Type) + "/docker-ce.repo",
+		}
+	default:
+		return nil
+	}
+}
+
+// Diretório do arquivo .repo da HashiCorp para cada distribuição baseada em rpm
+var hashicorpRPMDirs = map[utils.OSType]string{
+	utils.CentOS:      "RHEL",
+	utils.Fedora:      "fedora",
+	utils.AmazonLinux: "AmazonLinux",
+}
+
+// hashicorpRepository retorna o repositório oficial da HashiCorp; openSUSE,
+// Alpine e Arch não têm repositório e usam releases.hashicorp.com
+func hashicorpRepository(osInfo *utils.OSInfo) *pkgmgr.Repository {
+	switch osInfo.Type {
+	case utils.Ubuntu, utils.Debian:
+		return &pkgmgr.Repository{
+			Name:         "hashicorp",
+			Tool:         "terraform",
+			URL:          "https://apt.releases.hashicorp.com",
+			KeyURL:       "https://apt.releases.hashicorp.com/gpg",
+			Fingerprints: []string{hashicorpFingerprint},
+			Suite:        osInfo.Codename,
+			Components:   []string{"main"},
+		}
+	case utils.CentOS, utils.Fedora, utils.AmazonLinux:
+		return &pkgmgr.Repository{
+			Name:         "hashicorp",
+			Tool:         "terraform",
+			KeyURL:       "https://rpm.releases.hashicorp.com/gpg",
+			Fingerprints: []string{hashicorpFingerprint},
+			RepoFileURL:  "https://rpm.releases.hashicorp.com/" + hashicorpRPMDirs[osInfo.Type] + "/hashicorp.repo",
+		}
+	default:
+		return nil
+	}
+}
+
+// gcloudRPMArch traduz a arquitetura do Go para o nome usado no repositório rpm do Google Cloud
+var gcloudRPMArch = map[string]string{"amd64": "x86_64", "arm64": "aarch64"}
+
+// gcloudRepository retorna o repositório do Google Cloud CLI
+func gcloudRepository(osInfo *utils.OSInfo) *pkgmgr.Repository {
+	switch osInfo.Type {
+	case utils.Ubuntu, utils.Debian:
+		return &pkgmgr.Repository{
+			Name:         "google-cloud-sdk",
+			Tool:         "gcloud",
+			URL:          "https://packages.cloud.google.com/apt",
+			KeyURL:       "https://packages.cloud.google.com/apt/doc/apt-key.gpg",
+			Fingerprints: googleCloudFingerprints,
+			Suite:        "cloud-sdk",
+			Components:   []string{"main"},
+		}
+	case utils.CentOS, utils.Fedora, utils.AmazonLinux:
+		return &pkgmgr.Repository{
+			Name:         "google-cloud-sdk",
+			Tool:         "gcloud",
+			URL:          fmt.Sprintf("https://packages.cloud.google.com/yum/repos/cloud-sdk-%s-%s", gcloudEL(osInfo), gcloudRPMArch[osInfo.Arch]),
+			KeyURL:       "https://packages.cloud.google.com/yum/doc/rpm-package-key.gpg",
+			Fingerprints: googleCloudFingerprints,
+		}
+	default:
+		return nil
+	}
+}
+
+// gcloudEL retorna a versão do Enterprise Linux equivalente à distribuição,
+// que escolhe o repositório rpm do Google Cloud CLI: o Amazon Linux 2 é
+// baseado no EL7, o CentOS/RHEL 8 no EL8 e o Amazon Linux 2023, o Fedora e o
+// CentOS/RHEL 9 usam o EL9
+func gcloudEL(osInfo *utils.OSInfo) string {
+	switch {
+	case osInfo.Type == utils.AmazonLinux && osInfo.VersionID == "2":
+		return "el7"
+	case osInfo.Type == utils.CentOS && strings.HasPrefix(osInfo.VersionID, "8"):
+		return "el8"
+	default:
+		return "el9"
+	}
+}
+
+// azureRepository retorna o repositório da Microsoft para o Azure CLI, ou nil
+// onde a distribuição empacota o azure-cli (Arch) ou não há pacote (macOS usa
+// o Homebrew)
+func azureRepository(osInfo *utils.OSInfo) *pkgmgr.Repository {
+	switch osInfo.Type {
+	case utils.Ubuntu, utils.Debian:
+		return &pkgmgr.Repository{
+			Name:         "azure-cli",
+			Tool:         "az",
+			URL:          "https://packages.microsoft.com/repos/azure-cli",
+			KeyURL:       "https://packages.microsoft.com/keys/microsoft.asc",
+			Fingerprints: []string{microsoftFingerprint},
+			Suite:        osInfo.Codename,
+			Components:   []string{"main"},
+		}
+	case utils.CentOS, utils.Fedora, utils.AmazonLinux, utils.OpenSUSE:
+		return &pkgmgr.Repository{
+			Name:         "azure-cli",
+			Tool:         "az",
+			URL:          "https://packages.microsoft.com/yumrepos/azure-cli",
+			KeyURL:       "https://packages.microsoft.com/keys/microsoft.asc",
+			Fingerprints: []string{microsoftFingerprint},
+		}
+	default:
+		return nil
+	}
+}
+
+// ToolRepository retorna o repositório de terceiros usado pela ferramenta no
+// sistema informado, ou nil quando ela não depende de nenhum
+func ToolRepository(tool string, osInfo *utils.OSInfo) (*pkgmgr.Repository, error) {
+	if !IsValidTool(tool) {
+		return nil, fmt.Errorf("ferramenta não reconhecida: %s", tool)
+	}
+
+	spec, _ := toolSpec(tool, osInfo)
+	if spec.Repository == nil {
+		return nil, nil
+	}
+	return spec.Repository(osInfo), nil
+}
+
+// usesPackages verifica se a ferramenta é instalada pelo gerenciador de
+// pacotes no sistema informado
+func usesPackages(tool string, osInfo *utils.OSInfo) bool {
+	spec, ok := toolSpec(tool, osInfo)
+	return ok && spec.appliesTo(osInfo)
+}
+
+// toolSpec retorna os pacotes da ferramenta considerando o provedor
+// escolhido (ex: podman no lugar do Docker CE)
+func toolSpec(tool string, osInfo *utils.OSInfo) (packageSpec, bool) {
+	if tool == "docker" {
+		if provider := selectDockerProvider(osInfo); toolPackages[provider].Packages != nil {
+			tool = provider
+		}
+	}
+	spec, ok := toolPackages[tool]
+	return spec, ok
+}
+
+// installPackageTool instala a ferramenta com o gerenciador de pacotes do sistema
+func installPackageTool(tool string, osInfo *utils.OSInfo) error {
+	if err := installToolPackages([]string{tool}, osInfo); err != nil {
+		return err
+	}
+	return postInstallTool(tool, osInfo)
+}
+
+// installToolPackages instala os pacotes das ferramentas em uma única
+// transação do gerenciador de pacotes: configura todos os repositórios,
+// atualiza o índice uma vez e instala todos os pacotes juntos
+func installToolPackages(tools []string, osInfo *utils.OSInfo) error {
+	pm, err := pkgmgr.ForOS(osInfo)
+	if err != nil {
+		return err
+	}
+
+	var repos []pkgmgr.Repository
+	var pkgs []string
+	seen := make(map[string]bool)
+
+	for _, tool := range tools {
+		spec, ok := toolSpec(tool, osInfo)
+		if !ok {
+			return fmt.Errorf("nenhum pacote definido para %s", tool)
+		}
+
+		if spec.Repository != nil {
+			if repo := spec.Repository(osInfo); repo != nil {
+				repos = append(repos, *repo)
+			}
+		}
+
+		for _, pkg := range spec.For(osInfo.Type, pm.Name()) {
+			if !seen[pkg] {
+				seen[pkg] = true
+				pkgs = append(pkgs, pkg)
+			}
+		}
+	}
+
+	color.Blue("📦 Instalando %s com %s...", strings.Join(tools, ", "), pm.Name())
+	return installPackages(pm, tools, repos, pkgs)
+}
+
+// postInstallTool executa a configuração posterior da ferramenta, se houver
+func postInstallTool(tool string, osInfo *utils.OSInfo) error {
+	if spec, _ := toolSpec(tool, osInfo); spec.PostInstall != nil {
+		if err := spec.PostInstall(osInfo); err != nil {
+			return err
+		}
+	}
+
+	color.Green("✅ %s instalado com sucesso via %s!", tool, pkgmgr.NameFor(osInfo))
+	return nil
+}
+
+// installHelperPackages instala os pacotes auxiliares da ferramenta
+func installHelperPackages(tool string, osInfo *utils.OSInfo) error {
+	pm, err := pkgmgr.ForOS(osInfo)
+	if err != nil {
+		return err
+	}
+	return installPackages(pm, []string{tool}, nil, helperPackages[tool])
+}
+
+// installPackages configura os repositórios, atualiza o índice (apenas se
+// necessário) e instala os pacotes das ferramentas. Com um bundle offline
+// ativo, instala os arquivos .deb/.rpm do bundle no lugar dos pacotes.
+func installPackages(pm pkgmgr.PackageManager, tools []string, repos []pkgmgr.Repository, pkgs []string) error {
+	if b := bundle.Active(); b != nil {
+		return installBundledPackages(b, pm, tools, repos)
+	}
+
+	for _, repo := range repos {
+		if err := pm.AddRepository(repo); err != nil {
+			return fmt.Errorf("erro ao adicionar repositório %s: %w", repo.Name, err)
+		}
+	}
+
+	if err := pkgmgr.RefreshIndex(pm); err != nil {
+		return err
+	}
+
+	if err := pm.Install(pkgs...); err != nil {
+		return fmt.Errorf("erro ao instalar %v: %w", pkgs, err)
+	}
+	return nil
+}
+
+// installBundledPackages instala os pacotes das ferramentas a partir do
+// bundle. Apenas as chaves dos repositórios dessas ferramentas são
+// importadas, e somente depois de conferidos os fingerprints fixados.
+func installBundledPackages(b *bundle.Bundle, pm pkgmgr.PackageManager, tools []string, repos []pkgmgr.Repository) error {
+	local, ok := pm.(pkgmgr.LocalInstaller)
+	if !ok {
+		return fmt.Errorf("instalação offline não suportada com %s", pm.Name())
+	}
+
+	var files []string
+	for _, tool := range tools {
+		toolFiles := b.Files(tool, bundle.KindPackage)
+		if len(toolFiles) == 0 {
+			return fmt.Errorf("o bundle não contém os pacotes de %s", tool)
+		}
+		files = append(files, toolFiles...)
+	}
+
+	// Importar as chaves do fornecedor para que as assinaturas dos pacotes sejam verificadas
+	if importer, ok := pm.(pkgmgr.KeyImporter); ok {
+		for _, repo := range repos {
+			if repo.KeyURL == "" {
+				continue
+			}
+			key, ok := b.Lookup(repo.KeyURL)
+			if !ok {
+				return fmt.Errorf("o bundle não contém a chave do repositório %s", repo.Name)
+			}
+			if err := pkgmgr.VerifyKey(repo, key); err != nil {
+				return err
+			}
+			if err := importer.ImportKey(key); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := local.InstallFiles(files...); err != nil {
+		return fmt.Errorf("erro ao instalar pacotes de %s a partir do bundle: %w", strings.Join(tools, ", "), err)
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package installer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/matheusflausino/setup-devops-cli/internal/bundle"
+	"github.com/matheusflausino/setup-devops-cli/internal/config"
+	"github.com/matheusflausino/setup-devops-cli/internal/initsys"
+	"github.com/matheusflausino/setup-devops-cli/internal/pkgmgr"
+	"github.com/matheusflausino/setup-devops-cli/internal/privilege"
+	"github.com/matheusflausino/setup-devops-cli/internal/utils"
+)
+
+// PrivilegedCommands descreve os comandos que a instalação das ferramentas
+// executará como administrador, para que sejam listados antes de começar.
+// No macOS o Homebrew nunca é executado como administrador.
+func PrivilegedCommands(tools []string, osInfo *utils.OSInfo) []string {
+	if !osInfo.Type.IsLinux() {
+		return nil
+	}
+
+	pmName := pkgmgr.NameFor(osInfo)
+	var commands, repos, pkgs, binaries []string
+
+	for _, tool := range tools {
+		if IsToolInstalled(tool) {
+			continue
+		}
+
+		if usesPackages(tool, osInfo) {
+			spec, _ := toolSpec(tool, osInfo)
+			if spec.Repository != nil && bundle.Active() == nil {
+				if repo := spec.Repository(osInfo); repo != nil {
+					repos = append(repos, repo.Name)
+				}
+			}
+			pkgs = append(pkgs, spec.For(osInfo.Type, pmName)...)
+		} else {
+			for _, pkg := range helperPackages[tool] {
+				if !isCommandAvailable(pkg) {
+					pkgs = append(pkgs, pkg)
+				}
+			}
+			if releases[tool].Signature != "" && !isCommandAvailable("gpg") {
+				pkgs = append(pkgs, gpgPackage(osInfo))
+			}
+			binaries = append(binaries, tool)
+		}
+	}
+
+	// O krew é instalado junto quando há plugins do krew configurados
+	if len(config.KrewPlugins()) > 0 && contains(tools, "kubectl") && !contains(tools, "krew") && !IsToolInstalled("krew") && !usesPackages("krew", osInfo) {
+		binaries = append(binaries, "krew")
+	}
+
+	for _, repo := range repos {
+		commands = append(commands, fmt.Sprintf("%s: configurar o repositório %s (chave e arquivo de origem)", pmName, repo))
+	}
+	if len(pkgs) > 0 {
+		if bundle.Active() == nil {
+			commands = append(commands, fmt.Sprintf("%s: atualizar o índice de pacotes", pmName))
+		}
+		commands = append(commands, fmt.Sprintf("%s: instalar %s", pmName, strings.Join(unique(pkgs), " ")))
+	}
+	if len(binaries) > 0 && !isWritable(config.Prefix()) {
+		commands = append(commands, fmt.Sprintf("install: copiar %s para %s", strings.Join(binaries, ", "), config.Prefix()))
+	}
+
+	if contains(tools, "docker") && usesDockerCE(osInfo) {
+		installed := IsToolInstalled("docker")
+		if username, err := invokingUser(); err == nil && !installed && username != "root" && !inGroup(username, "docker") {
+			commands = append(commands, fmt.Sprintf("usermod: adicionar %s ao grupo docker (criando o grupo, se necessário)", username))
+		}
+
+		// docker.daemon também é reaplicado com o Docker já instalado
+		daemon := dockerDaemonPending()
+		if daemon {
+			commands = append(commands, fmt.Sprintf("install: mesclar docker.daemon em %s", dockerDaemonConfig))
+		}
+		if initSystem := initsys.Detect(); initSystem.Managed() {
+			switch {
+			case !installed:
+				commands = append(commands, fmt.Sprintf("%s: iniciar e habilitar o serviço docker", initSystem.Name()))
+			case daemon:
+				commands = append(commands, fmt.Sprintf("%s: reiniciar o serviço docker", initSystem.Name()))
+			}
+		}
+	}
+
+	if contains(tools, "docker") && osInfo.Type.IsLinux() && usesDockerPlugins(osInfo) && !isWritable(config.Prefix()) {
+		commands = append(commands, fmt.Sprintf("install: copiar os plugins compose e buildx para %s", dockerPluginsDir()))
+	}
+
+	return commands
+}
+
+// usesDockerCE verifica se o Docker é (ou será) fornecido pelo Docker CE,
+// o único provedor em que a CLI configura o daemon
+func usesDockerCE(osInfo *utils.OSInfo) bool {
+	if IsToolInstalled("docker") {
+		return InstalledDockerProvider() == providerDockerCE
+	}
+	return selectDockerProvider(osInfo) == providerDockerCE && usesPackages("docker", osInfo)
+}
+
+// preparePrivileges lista os comandos privilegiados da instalação e valida
+// as credenciais antes de começar
+func preparePrivileges(tools []string, osInfo *utils.OSInfo) error {
+	return privilege.Prepare(PrivilegedCommands(tools, osInfo))
+}
+
+// unique remove itens repetidos mantendo a ordem
+func unique(items []string) []string {
+	seen := make(map[string]bool, len(items))
+	result := make([]string, 0, len(items))
+	for _, item := range items {
+		if !seen[item] {
+			seen[item] = true
+			result = append(result, item)
+		}
+	}
+	return result
+}
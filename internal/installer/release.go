@@ -0,0 +1,340 @@
+package installer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/matheusflausino/setup-devops-cli/internal/config"
+	"github.com/matheusflausino/setup-devops-cli/internal/download"
+	"github.com/matheusflausino/setup-devops-cli/internal/pkgmgr"
+	"github.com/matheusflausino/setup-devops-cli/internal/utils"
+)
+
+// binaryRelease descreve uma ferramenta distribuída como binário ou arquivo .tar.gz/.zip
+type binaryRelease struct {
+	Tool    string
+	Version string
+	// URL aceita os marcadores {version} (ex: v1.28.0), {number} (ex: 1.28.0),
+	// {os} (ex: linux) e {arch} (ex: amd64)
+	URL string
+	// Binary é o nome do executável instalado em <prefix>/bin
+	Binary string
+	// ArchivePath é o caminho do executável dentro do .tar.gz/.zip (vazio para binário puro)
+	ArchivePath string
+	// Arch traduz a arquitetura do Go para o nome usado na URL, quando diferente
+	Arch map[string]string
+	// Checksums é a URL do arquivo de checksums SHA256 publicado com a release
+	// (aceita os mesmos marcadores); quando definida, o download é verificado
+	Checksums string
+	// Signature é a URL da assinatura GPG destacada do arquivo de checksums,
+	// verificada com a chave de KeyURL, cujo fingerprint deve ser um dos
+	// fixados em Fingerprints
+	Signature    string
+	KeyURL       string
+	Fingerprints []string
+}
+
+// Ferramentas instaladas a partir de binários no Linux
+var releases = map[string]binaryRelease{
+	"kubectl": {
+		Tool:    "kubectl",
+		Version: "v1.28.0",
+		URL:     "https://dl.k8s.io/release/{version}/bin/linux/{arch}/kubectl",
+		Binary:  "kubectl",
+	},
+	"helm": {
+		Tool:        "helm",
+		Version:     "v3.12.0",
+		URL:         "https://get.helm.sh/helm-{version}-linux-{arch}.tar.gz",
+		Binary:      "helm",
+		ArchivePath: "linux-{arch}/helm",
+	},
+	"helmfile": {
+		Tool:        "helmfile",
+		Version:     "v0.162.0",
+		URL:         "https://github.com/helmfile/helmfile/releases/download/{version}/helmfile_{number}_linux_{arch}.tar.gz",
+		Binary:      "helmfile",
+		ArchivePath: "helmfile",
+	},
+	"k9s": {
+		Tool:        "k9s",
+		Version:     "v0.32.4",
+		URL:         "https://github.com/derailed/k9s/releases/download/{version}/k9s_Linux_{arch}.tar.gz",
+		Binary:      "k9s",
+		ArchivePath: "k9s",
+	},
+	"kind": {
+		Tool:    "kind",
+		Version: "v0.24.0",
+		URL:     "https://kind.sigs.k8s.io/dl/{version}/kind-linux-{arch}",
+		Binary:  "kind",
+	},
+	"minikube": {
+		Tool:    "minikube",
+		Version: "v1.34.0",
+		URL:     "https://storage.googleapis.com/minikube/releases/{version}/minikube-linux-{arch}",
+		Binary:  "minikube",
+	},
+	"k3d": {
+		Tool:    "k3d",
+		Version: "v5.7.4",
+		URL:     "https://github.com/k3d-io/k3d/releases/download/{version}/k3d-linux-{arch}",
+		Binary:  "k3d",
+	},
+	"kubectx": {
+		Tool:        "kubectx",
+		Version:     "v0.9.5",
+		URL:         "https://github.com/ahmetb/kubectx/releases/download/{version}/kubectx_{version}_linux_{arch}.tar.gz",
+		Binary:      "kubectx",
+		ArchivePath: "kubectx",
+		Arch:        map[string]string{"amd64": "x86_64"},
+	},
+	"kubens": {
+		Tool:        "kubens",
+		Version:     "v0.9.5",
+		URL:         "https://github.com/ahmetb/kubectx/releases/download/{version}/kubens_{version}_linux_{arch}.tar.gz",
+		Binary:      "kubens",
+		ArchivePath: "kubens",
+		Arch:        map[string]string{"amd64": "x86_64"},
+	},
+	"stern": {
+		Tool:        "stern",
+		Version:     "v1.31.0",
+		URL:         "https://github.com/stern/stern/releases/download/{version}/stern_{number}_linux_{arch}.tar.gz",
+		Binary:      "stern",
+		ArchivePath: "stern",
+	},
+	"kustomize": {
+		Tool:        "kustomize",
+		Version:     "v5.4.3",
+		URL:         "https://github.com/kubernetes-sigs/kustomize/releases/download/kustomize%2F{version}/kustomize_{version}_linux_{arch}.tar.gz",
+		Binary:      "kustomize",
+		ArchivePath: "kustomize",
+	},
+	// O krew é instalado como plugin do kubectl (kubectl krew)
+	"krew": {
+		Tool:        "krew",
+		Version:     "v0.4.4",
+		URL:         "https://github.com/kubernetes-sigs/krew/releases/download/{version}/krew-linux_{arch}.tar.gz",
+		Binary:      "kubectl-krew",
+		ArchivePath: "krew-linux_{arch}",
+	},
+	// Grupo iac: ferramentas do ecossistema Terraform, com checksums verificados
+	"terragrunt": {
+		Tool:      "terragrunt",
+		Version:   "v0.67.16",
+		URL:       "https://github.com/gruntwork-io/terragrunt/releases/download/{version}/terragrunt_linux_{arch}",
+		Binary:    "terragrunt",
+		Checksums: "https://github.com/gruntwork-io/terragrunt/releases/download/{version}/SHA256SUMS",
+	},
+	"tflint": {
+		Tool:        "tflint",
+		Version:     "v0.53.0",
+		URL:         "https://github.com/terraform-linters/tflint/releases/download/{version}/tflint_linux_{arch}.zip",
+		Binary:      "tflint",
+		ArchivePath: "tflint",
+		Checksums:   "https://github.com/terraform-linters/tflint/releases/download/{version}/checksums.txt",
+	},
+	// O trivy substitui o tfsec, que foi incorporado a ele
+	"trivy": {
+		Tool:        "trivy",
+		Version:     "v0.56.2",
+		URL:         "https://github.com/aquasecurity/trivy/releases/download/{version}/trivy_{number}_Linux-{arch}.tar.gz",
+		Binary:      "trivy",
+		ArchivePath: "trivy",
+		Arch:        map[string]string{"amd64": "64bit", "arm64": "ARM64"},
+		Checksums:   "https://github.com/aquasecurity/trivy/releases/download/{version}/trivy_{number}_checksums.txt",
+	},
+	"terraform-docs": {
+		Tool:        "terraform-docs",
+		Version:     "v0.19.0",
+		URL:         "https://github.com/terraform-docs/terraform-docs/releases/download/{version}/terraform-docs-{version}-linux-{arch}.tar.gz",
+		Binary:      "terraform-docs",
+		ArchivePath: "terraform-docs",
+		Checksums:   "https://github.com/terraform-docs/terraform-docs/releases/download/{version}/terraform-docs-{version}.sha256sum",
+	},
+	// Produtos da HashiCorp (releases.hashicorp.com), usados sem repositório
+	// da HashiCorp ou com a versão fixada
+	"terraform": hashicorpRelease("terraform", "v1.9.8"),
+	"vault":     hashicorpRelease("vault", "v1.18.0"),
+	"packer":    hashicorpRelease("packer", "v1.11.2"),
+	"consul":    hashicorpRelease("consul", "v1.20.0"),
+}
+
+// releaseFor retorna a release da ferramenta com a versão fixada em
+// versions.<ferramenta>, quando configurada
+func releaseFor(tool string) binaryRelease {
+	rel := releases[tool]
+	if version := config.ToolVersion(tool); version != "" {
+		rel.Version = withV(version)
+	}
+	return rel
+}
+
+// expand substitui os marcadores de versão, sistema e arquitetura em s
+func (r binaryRelease) expand(s, goos, arch string) string {
+	if name, ok := r.Arch[arch]; ok {
+		arch = name
+	}
+
+	return strings.NewReplacer(
+		"{version}", r.Version,
+		"{number}", strings.TrimPrefix(r.Version, "v"),
+		"{os}", goos,
+		"{arch}", arch,
+	).Replace(s)
+}
+
+// downloadURL retorna a URL de download para o sistema e a arquitetura informados
+func (r binaryRelease) downloadURL(goos, arch string) string {
+	return r.expand(r.URL, goos, arch)
+}
+
+// installRelease baixa a release e a instala no layout versionado do prefixo
+func installRelease(rel binaryRelease) error {
+	if err := downloadRelease(rel); err != nil {
+		return err
+	}
+
+	if err := runInPrefix("mkdir", "-p", binDir()); err != nil {
+		return fmt.Errorf("erro ao criar diretório %s: %w", binDir(), err)
+	}
+	if err := activateVersion(rel.Tool, rel.Version, rel.Binary); err != nil {
+		return fmt.Errorf("erro ao instalar %s: %w", rel.Tool, err)
+	}
+
+	color.Green("✅ %s %s instalado com sucesso em %s!", rel.Tool, rel.Version, binDir())
+	return nil
+}
+
+// downloadRelease baixa a release e a copia para o diretório da versão no
+// layout do prefixo, sem ativá-la
+func downloadRelease(rel binaryRelease) error {
+	color.Blue("📦 Instalando %s %s em %s...", rel.Tool, rel.Version, toolVersionDir(rel.Tool, rel.Version))
+
+	tmpDir, err := os.MkdirTemp("", "setup-devops-"+rel.Tool+"-")
+	if err != nil {
+		return fmt.Errorf("erro ao criar diretório temporário: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	binary, err := fetchRelease(rel, tmpDir)
+	if err != nil {
+		return err
+	}
+
+	if err := copyVersionedBinary(rel.Tool, rel.Version, binary, rel.Binary); err != nil {
+		return fmt.Errorf("erro ao instalar %s: %w", rel.Tool, err)
+	}
+	return nil
+}
+
+// installReleaseHelpers instala as ferramentas auxiliares de que a release
+// precisa: unzip para arquivos .zip e gpg para releases assinadas
+func installReleaseHelpers(rel binaryRelease, osInfo *utils.OSInfo) error {
+	if strings.HasSuffix(rel.URL, ".zip") && !isCommandAvailable("unzip") {
+		if err := installHelperPackages(rel.Tool, osInfo); err != nil {
+			return fmt.Errorf("erro ao instalar unzip: %w", err)
+		}
+	}
+	if rel.Signature != "" && !isCommandAvailable("gpg") {
+		return installGPG(rel.Tool, osInfo)
+	}
+	return nil
+}
+
+// fetchRelease baixa a release em tmpDir, extraindo o arquivo compactado se
+// necessário, e retorna o caminho do executável
+func fetchRelease(rel binaryRelease, tmpDir string) (string, error) {
+	url := rel.downloadURL(runtime.GOOS, runtime.GOARCH)
+	file := filepath.Join(tmpDir, filepath.Base(url))
+
+	// Baixar, conferindo o checksum publicado na release quando houver
+	if rel.Checksums == "" {
+		if err := download.Fetch(rel.Tool, url, file); err != nil {
+			return "", fmt.Errorf("erro ao baixar %s: %w", rel.Tool, err)
+		}
+	} else {
+		sum, err := releaseChecksum(rel, filepath.Base(file), tmpDir)
+		if err != nil {
+			return "", err
+		}
+		if err := download.FetchVerified(rel.Tool, url, file, sum); err != nil {
+			return "", fmt.Errorf("erro ao baixar %s: %w", rel.Tool, err)
+		}
+		color.Green("🔒 Checksum de %s verificado", filepath.Base(file))
+	}
+
+	// Extrair, se for um arquivo compactado
+	if rel.ArchivePath == "" {
+		return file, nil
+	}
+
+	extract := []string{"tar", "-xzf", file, "-C", tmpDir}
+	if strings.HasSuffix(file, ".zip") {
+		extract = []string{"unzip", "-q", "-o", file, "-d", tmpDir}
+	}
+	if err := utils.RunCommand(extract[0], extract[1:]...); err != nil {
+		return "", fmt.Errorf("erro ao extrair %s: %w", rel.Tool, err)
+	}
+	return filepath.Join(tmpDir, rel.expand(rel.ArchivePath, runtime.GOOS, runtime.GOARCH)), nil
+}
+
+// releaseChecksum baixa o arquivo de checksums da release (conferindo sua
+// assinatura, se houver) e retorna o checksum SHA256 esperado para name
+func releaseChecksum(rel binaryRelease, name, tmpDir string) (string, error) {
+	url := rel.expand(rel.Checksums, runtime.GOOS, runtime.GOARCH)
+	sums := filepath.Join(tmpDir, "checksums-"+filepath.Base(url))
+
+	// Os checksums e a assinatura não usam o cache: são eles que definem a
+	// chave do artefato no cache
+	if _, err := download.FetchWithBase(rel.Tool, url, sums); err != nil {
+		return "", fmt.Errorf("erro ao baixar os checksums de %s: %w", rel.Tool, err)
+	}
+
+	if rel.Signature != "" {
+		if err := verifySignature(rel, sums, tmpDir); err != nil {
+			return "", err
+		}
+	}
+
+	content, err := os.ReadFile(sums)
+	if err != nil {
+		return "", fmt.Errorf("erro ao ler os checksums de %s: %w", rel.Tool, err)
+	}
+
+	// Formato do sha256sum: "<checksum>  <arquivo>" (ou "*<arquivo>" no modo binário)
+	for _, line := range strings.Split(string(content), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 2 && strings.TrimPrefix(strings.TrimPrefix(fields[1], "*"), "./") == name {
+			return fields[0], nil
+		}
+	}
+	return "", fmt.Errorf("checksum de %s não encontrado em %s", name, filepath.Base(url))
+}
+
+// verifySignature baixa a chave e a assinatura do arquivo de checksums e
+// confere a assinatura com a chave fixada
+func verifySignature(rel binaryRelease, sums, tmpDir string) error {
+	sigURL := rel.expand(rel.Signature, runtime.GOOS, runtime.GOARCH)
+	sig := filepath.Join(tmpDir, "signature-"+filepath.Base(sigURL))
+	if _, err := download.FetchWithBase(rel.Tool, sigURL, sig); err != nil {
+		return fmt.Errorf("erro ao baixar a assinatura dos checksums de %s: %w", rel.Tool, err)
+	}
+
+	// A chave não usa o cache: ela pode mudar sem mudar de URL
+	key := filepath.Join(tmpDir, "key-"+filepath.Base(rel.KeyURL))
+	if _, err := download.FetchWithBase(rel.Tool, rel.KeyURL, key); err != nil {
+		return fmt.Errorf("erro ao baixar a chave de %s: %w", rel.Tool, err)
+	}
+
+	if err := pkgmgr.VerifySignature(rel.Tool, key, sig, sums, rel.Fingerprints); err != nil {
+		return err
+	}
+	color.Green("🔏 Assinatura dos checksums de %s verificada", rel.Tool)
+	return nil
+}
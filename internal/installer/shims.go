@@ -0,0 +1,139 @@
+package installer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/matheusflausino/setup-devops-cli/internal/config"
+	"github.com/matheusflausino/setup-devops-cli/internal/project"
+	"github.com/matheusflausino/setup-devops-cli/internal/utils"
+)
+
+// ShimEnv é definida pelos shims ao chamar a CLI, para que ela não escreva
+// nada além da saída da própria ferramenta
+const ShimEnv = "SETUP_DEVOPS_SHIM"
+
+// Resolution descreve o executável que um shim vai executar
+type Resolution struct {
+	Tool    string
+	Version string
+	Path    string
+	// Source explica de onde veio a versão (arquivo do projeto, versão
+	// ativa no prefixo ou executável do sistema)
+	Source string
+}
+
+// InstallShims cria em config.ShimsDir() um shim para cada ferramenta com
+// versões por projeto, chamando o executável da CLI informado
+func InstallShims(executable string) error {
+	dir := config.ShimsDir()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("erro ao criar diretório %s: %w", dir, err)
+	}
+
+	quoted := "'" + strings.ReplaceAll(executable, "'", `'\''`) + "'"
+	for _, tool := range VersionedTools {
+		script := fmt.Sprintf("#!/bin/sh\n# Shim do setup-devops: executa a versão de %s fixada pelo projeto\n%s=1 exec %s exec %s \"$@\"\n",
+			tool, ShimEnv, quoted, tool)
+
+		path := filepath.Join(dir, releases[tool].Binary)
+		if err := os.WriteFile(path, []byte(script), 0o755); err != nil {
+			return fmt.Errorf("erro ao criar shim %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// RemoveShims remove os shims criados por InstallShims
+func RemoveShims() error {
+	if err := os.RemoveAll(config.ShimsDir()); err != nil {
+		return fmt.Errorf("erro ao remover os shims: %w", err)
+	}
+	return nil
+}
+
+// ShimsInPath verifica se o diretório dos shims está no PATH antes de
+// <prefix>/bin, como é necessário para que eles tenham efeito
+func ShimsInPath() bool {
+	shims, bin := config.ShimsDir(), binDir()
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		switch filepath.Clean(dir) {
+		case shims:
+			return true
+		case bin:
+			return false
+		}
+	}
+	return false
+}
+
+// ResolveTool decide qual executável da ferramenta usar no diretório dir:
+// a versão fixada pelo projeto (instalada no primeiro uso, se permitido por
+// shims.autoInstall), senão a versão ativa em <prefix>/bin, senão o
+// executável do sistema encontrado no PATH
+func ResolveTool(tool, dir string) (*Resolution, error) {
+	if !IsVersionedTool(tool) {
+		return nil, fmt.Errorf("versões por projeto não suportadas para %s (use: %s)", tool, strings.Join(VersionedTools, ", "))
+	}
+
+	binary := releases[tool].Binary
+
+	pin, err := project.Find(dir, tool)
+	if err != nil {
+		return nil, err
+	}
+	if pin != nil {
+		if err := checkVersion(tool, pin.Version); err != nil {
+			return nil, fmt.Errorf("%w em %s", err, pin.File)
+		}
+		version := withV(pin.Version)
+		path := filepath.Join(toolVersionDir(tool, version), binary)
+		if _, err := os.Stat(path); err != nil {
+			if !config.ShimsAutoInstall() {
+				return nil, fmt.Errorf("%s %s (fixado em %s) não está instalado; habilite shims.autoInstall (ou SETUP_DEVOPS_AUTO_INSTALL=true) para instalá-lo no primeiro uso", tool, version, pin.File)
+			}
+			if err := installPinnedVersion(tool, version); err != nil {
+				return nil, err
+			}
+		}
+		return &Resolution{Tool: tool, Version: version, Path: path, Source: pin.File}, nil
+	}
+
+	link := filepath.Join(binDir(), binary)
+	if _, err := os.Stat(link); err == nil {
+		return &Resolution{Tool: tool, Version: ActiveVersion(tool), Path: link, Source: "versão ativa em " + binDir()}, nil
+	}
+
+	// utils.LookPath ignora o diretório dos shims
+	if path, err := utils.LookPath(binary); err == nil {
+		return &Resolution{Tool: tool, Path: path, Source: "sistema"}, nil
+	}
+	return nil, fmt.Errorf("%s não está instalado; execute 'setup-devops install %s'", tool, tool)
+}
+
+// installPinnedVersion instala a versão fixada pelo projeto no layout do
+// prefixo sem ativá-la, já que ela vale apenas para o projeto
+func installPinnedVersion(tool, version string) error {
+	osInfo, err := utils.GetOSInfo()
+	if err != nil {
+		return fmt.Errorf("erro ao detectar sistema operacional: %w", err)
+	}
+	if !osInfo.Type.IsLinux() {
+		return fmt.Errorf("versões por projeto não suportadas em %s: as ferramentas vêm do Homebrew", osInfo)
+	}
+
+	rel := releases[tool]
+	rel.Version = version
+	if err := installReleaseHelpers(rel, osInfo); err != nil {
+		return err
+	}
+	if err := downloadRelease(rel); err != nil {
+		return err
+	}
+
+	color.Green("✅ %s %s instalado para o projeto", tool, version)
+	return nil
+}
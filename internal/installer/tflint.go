@@ -0,0 +1,45 @@
+package installer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/fatih/color"
+	"github.com/matheusflausino/setup-devops-cli/internal/config"
+	"github.com/matheusflausino/setup-devops-cli/internal/utils"
+)
+
+// tflintConfigFile retorna o caminho absoluto do arquivo de configuração do
+// tflint (tflint.config, padrão .tflint.hcl no diretório atual), ou "" se
+// ele não existir
+func tflintConfigFile() string {
+	path, err := filepath.Abs(config.ExpandHome(config.TflintConfig()))
+	if err != nil {
+		return ""
+	}
+	if _, err := os.Stat(path); err != nil {
+		return ""
+	}
+	return path
+}
+
+// initTflint instala os plugins (rulesets) declarados no .tflint.hcl
+func initTflint() error {
+	path := tflintConfigFile()
+	if path == "" {
+		return nil
+	}
+
+	if !isCommandAvailable("tflint") {
+		color.Yellow("⚠️  tflint não está instalado; plugins de %s não foram instalados", path)
+		return nil
+	}
+
+	if output, err := utils.RunCommandCapture("tflint", "--init", "--config", path); err != nil {
+		return fmt.Errorf("erro ao instalar os plugins do tflint: %s", utils.LastLines(output))
+	}
+
+	color.Green("✅ Plugins do tflint instalados a partir de %s", path)
+	return nil
+}
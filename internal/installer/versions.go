@@ -0,0 +1,200 @@
+package installer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/matheusflausino/setup-devops-cli/internal/utils"
+)
+
+// VersionedTools são as ferramentas com várias versões lado a lado no
+// layout do prefixo, trocadas com "setup-devops use"
+var VersionedTools = []string{"terraform", "kubectl", "helm"}
+
+// IsVersionedTool verifica se a ferramenta suporta a troca de versão
+func IsVersionedTool(tool string) bool {
+	return contains(VersionedTools, tool)
+}
+
+// InstalledVersion descreve uma versão de uma ferramenta instalada no layout
+type InstalledVersion struct {
+	Version string
+	Path    string
+	Active  bool
+}
+
+// InstalledVersions lista as versões da ferramenta instaladas em
+// <prefix>/opt/<ferramenta>, da mais recente para a mais antiga
+func InstalledVersions(tool string) ([]InstalledVersion, error) {
+	if !IsVersionedTool(tool) {
+		return nil, fmt.Errorf("troca de versão não suportada para %s (use: %s)", tool, strings.Join(VersionedTools, ", "))
+	}
+
+	entries, err := os.ReadDir(toolDir(tool))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar versões de %s: %w", tool, err)
+	}
+
+	binary := releases[tool].Binary
+	active := ActiveVersion(tool)
+
+	var versions []InstalledVersion
+	for _, entry := range entries {
+		path := filepath.Join(toolVersionDir(tool, entry.Name()), binary)
+		if _, err := os.Stat(path); err != nil || !entry.IsDir() {
+			continue
+		}
+		versions = append(versions, InstalledVersion{
+			Version: entry.Name(),
+			Path:    path,
+			Active:  entry.Name() == active,
+		})
+	}
+
+	sort.Slice(versions, func(i, j int) bool {
+		return compareVersions(versions[i].Version, versions[j].Version) > 0
+	})
+	return versions, nil
+}
+
+// ActiveVersion retorna a versão para a qual <prefix>/bin/<binário> aponta,
+// ou "" se o link não existir ou não apontar para o layout
+func ActiveVersion(tool string) string {
+	target, err := os.Readlink(filepath.Join(binDir(), releases[tool].Binary))
+	if err != nil {
+		return ""
+	}
+
+	rel, err := filepath.Rel(toolDir(tool), target)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return ""
+	}
+	return strings.Split(rel, string(filepath.Separator))[0]
+}
+
+// UseVersion ativa a versão da ferramenta em <prefix>/bin, instalando-a
+// antes a partir das releases oficiais se ela ainda não estiver no layout
+func UseVersion(tool, version string, osInfo *utils.OSInfo) error {
+	if !IsVersionedTool(tool) {
+		return fmt.Errorf("troca de versão não suportada para %s (use: %s)", tool, strings.Join(VersionedTools, ", "))
+	}
+	if !osInfo.Type.IsLinux() {
+		return fmt.Errorf("troca de versão não suportada em %s: as ferramentas vêm do Homebrew", osInfo)
+	}
+
+	if err := checkVersion(tool, version); err != nil {
+		return err
+	}
+
+	rel := releases[tool]
+	rel.Version = withV(version)
+
+	if _, err := os.Stat(filepath.Join(toolVersionDir(tool, rel.Version), rel.Binary)); err != nil {
+		if err := installReleaseHelpers(rel, osInfo); err != nil {
+			return err
+		}
+
+		// installRelease já ativa a versão instalada
+		if err := installRelease(rel); err != nil {
+			return err
+		}
+	} else if err := activateVersion(tool, rel.Version, rel.Binary); err != nil {
+		return err
+	}
+
+	color.Green("✅ %s %s ativado em %s", tool, rel.Version, binDir())
+	warnShadowed(tool)
+	return nil
+}
+
+// validVersion aceita versões como 1.5.7, v1.29.4 e 1.9.0-rc1
+var validVersion = regexp.MustCompile(`^v?[0-9]+(\.[0-9]+)*(-[0-9A-Za-z.]+)?$`)
+
+// checkVersion rejeita versões que não são números de versão, já que a
+// versão vira um diretório em <prefix>/opt/<ferramenta> (ex: "../../bin")
+func checkVersion(tool, version string) error {
+	if !validVersion.MatchString(version) || strings.Contains(version, "..") {
+		return fmt.Errorf("versão inválida de %s: %q", tool, version)
+	}
+	return nil
+}
+
+// ShadowingBinary retorna o executável da ferramenta que o PATH resolve
+// fora de <prefix>/bin (ex: o pacote do sistema em /usr/bin), ou ""
+func ShadowingBinary(tool string) string {
+	binary := releases[tool].Binary
+	if path, err := utils.LookPath(binary); err == nil && path != filepath.Join(binDir(), binary) {
+		return path
+	}
+	return ""
+}
+
+// warnShadowed avisa quando outro executável com o mesmo nome vem antes de
+// <prefix>/bin no PATH
+func warnShadowed(tool string) {
+	if path := ShadowingBinary(tool); path != "" {
+		color.Yellow("⚠️  %s vem antes de %s no PATH; a versão ativa só é usada com %s antes no PATH", path, binDir(), binDir())
+	}
+}
+
+// compareVersions compara versões (v1.5.7, 1.10.0, 1.9.0-rc1) retornando
+// -1, 0 ou 1. As partes numéricas são comparadas como números, e uma versão
+// com sufixo de pré-release é menor que a versão final correspondente.
+func compareVersions(a, b string) int {
+	coreA, preA, _ := strings.Cut(strings.TrimPrefix(a, "v"), "-")
+	coreB, preB, _ := strings.Cut(strings.TrimPrefix(b, "v"), "-")
+
+	if c := compareParts(coreA, coreB); c != 0 {
+		return c
+	}
+
+	switch {
+	case preA == preB:
+		return 0
+	case preA == "":
+		return 1
+	case preB == "":
+		return -1
+	default:
+		return compareParts(preA, preB)
+	}
+}
+
+// compareParts compara as partes separadas por ponto, como números quando
+// as duas são numéricas e como texto nos demais casos
+func compareParts(a, b string) int {
+	pa := strings.Split(a, ".")
+	pb := strings.Split(b, ".")
+
+	for i := 0; i < len(pa) || i < len(pb); i++ {
+		var sa, sb string
+		if i < len(pa) {
+			sa = pa[i]
+		}
+		if i < len(pb) {
+			sb = pb[i]
+		}
+
+		na, errA := strconv.Atoi(sa)
+		nb, errB := strconv.Atoi(sb)
+		switch {
+		case errA == nil && errB == nil && na != nb:
+			if na < nb {
+				return -1
+			}
+			return 1
+		case (errA != nil || errB != nil) && sa != sb:
+			return strings.Compare(sa, sb)
+		}
+	}
+	return 0
+}
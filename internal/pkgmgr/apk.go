@@ -0,0 +1,171 @@
+package pkgmgr
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/matheusflausino/setup-devops-cli/internal/utils"
+)
+
+// apk gerencia pacotes no Alpine Linux
+type apk struct{}
+
+func (a *apk) Name() string {
+	return "apk"
+}
+
+func (a *apk) Update() error {
+	if err := runManager("apk", "apk", "update"); err != nil {
+		return fmt.Errorf("erro ao atualizar repositórios: %w", err)
+	}
+	return nil
+}
+
+func (a *apk) Install(pkgs ...string) error {
+	return runManager("apk", "apk", append([]string{"add", "--no-interactive"}, pkgs...)...)
+}
+
+func (a *apk) Remove(pkgs ...string) error {
+	return runManager("apk", "apk", append([]string{"del", "--no-interactive"}, pkgs...)...)
+}
+
+func (a *apk) IsInstalled(pkg string) bool {
+	_, err := a.InstalledVersion(pkg)
+	return err == nil
+}
+
+func (a *apk) InstalledVersion(pkg string) (string, error) {
+	// Saída: "<pacote>-<versão>-r<revisão> <arch> {<origem>} (<licença>) [installed]"
+	output, err := utils.RunCommandOutput("apk", "list", "--installed", pkg)
+	if err == nil {
+		for _, line := range strings.Split(output, "\n") {
+			fields := strings.Fields(line)
+			if len(fields) > 0 && strings.HasPrefix(fields[0], pkg+"-") {
+				return strings.TrimPrefix(fields[0], pkg+"-"), nil
+			}
+		}
+	}
+	return "", fmt.Errorf("pacote %s não está instalado", pkg)
+}
+
+// AddRepository grava a chave de assinatura em /etc/apk/keys, após conferir
+// o fingerprint, e adiciona a URL do repositório a /etc/apk/repositories
+func (a *apk) AddRepository(repo Repository) error {
+	repoURL := repo.URL
+
+	if repo.KeyURL != "" {
+		tmpDir, err := os.MkdirTemp("", "setup-devops-key-")
+		if err != nil {
+			return fmt.Errorf("erro ao criar diretório temporário: %w", err)
+		}
+		defer os.RemoveAll(tmpDir)
+
+		keyFile, rewritten, err := fetchVerifiedKey(repo, tmpDir)
+		if err != nil {
+			return err
+		}
+		repoURL = rewritten
+
+		// As chaves do apk são RSA (não GPG) e são gravadas como publicadas
+		key, err := os.ReadFile(keyFile)
+		if err != nil {
+			return err
+		}
+		if err := writeRootFile(apkKeyPath(repo), string(key), 0o644); err != nil {
+			return err
+		}
+	}
+
+	lines, err := apkRepositories()
+	if err != nil {
+		return err
+	}
+
+	for _, line := range lines {
+		if strings.TrimSpace(line) == repoURL {
+			return nil
+		}
+	}
+
+	return writeRootFile(apkRepositoriesFile, strings.Join(append(lines, repoURL), "\n")+"\n", 0o644)
+}
+
+// RemoveRepository remove a URL de /etc/apk/repositories e a chave do repositório
+func (a *apk) RemoveRepository(repo Repository) error {
+	lines, err := apkRepositories()
+	if err != nil {
+		return err
+	}
+
+	// A URL gravada pode ter sido reescrita para um mirror: comparar pelo caminho
+	path := repoPath(repo.URL)
+	var kept []string
+	for _, line := range lines {
+		line := strings.TrimSpace(line)
+		if line == repo.URL || (path != "" && path != "/" && strings.HasSuffix(line, path)) {
+			continue
+		}
+		kept = append(kept, line)
+	}
+
+	if len(kept) != len(lines) {
+		if err := writeRootFile(apkRepositoriesFile, strings.Join(kept, "\n")+"\n", 0o644); err != nil {
+			return err
+		}
+	}
+
+	if repo.KeyURL != "" {
+		return removeRootFile(apkKeyPath(repo))
+	}
+	return nil
+}
+
+// apkRepositoriesFile lista os repositórios do apk, um por linha
+const apkRepositoriesFile = "/etc/apk/repositories"
+
+// apkRepositories retorna as linhas de /etc/apk/repositories
+func apkRepositories() ([]string, error) {
+	current, err := os.ReadFile(apkRepositoriesFile)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler %s: %w", apkRepositoriesFile, err)
+	}
+	return strings.Split(strings.TrimRight(string(current), "\n"), "\n"), nil
+}
+
+// apkKeysDir guarda as chaves com que o apk verifica os pacotes
+const apkKeysDir = "/etc/apk/keys"
+
+// apkKeyPath retorna onde a chave do repositório é gravada
+func apkKeyPath(repo Repository) string {
+	return filepath.Join(apkKeysDir, filepath.Base(repo.KeyURL))
+}
+
+// repoPath retorna a URL sem o esquema e o host
+func repoPath(rawURL string) string {
+	if u, err := url.Parse(rawURL); err == nil {
+		return u.Path
+	}
+	return rawURL
+}
+
+// InstallFiles instala pacotes .apk locais sem acessar a rede, verificando
+// antes as assinaturas com as chaves de /etc/apk/keys
+func (a *apk) InstallFiles(files ...string) error {
+	if err := utils.RunCommand("apk", append([]string{"verify"}, files...)...); err != nil {
+		return fmt.Errorf("assinatura inválida nos pacotes: %w", err)
+	}
+	return runManager("apk", "apk", append([]string{"add", "--no-interactive", "--no-network"}, files...)...)
+}
+
+// ImportKey grava a chave RSA em /etc/apk/keys com o nome original, que é o
+// nome referenciado pelas assinaturas dos pacotes
+func (a *apk) ImportKey(path string) error {
+	key, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("erro ao ler chave %s: %w", path, err)
+	}
+	return writeRootFile(filepath.Join(apkKeysDir, filepath.Base(path)), string(key), 0o644)
+}
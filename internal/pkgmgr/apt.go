@@ -0,0 +1,186 @@
+package pkgmgr
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/matheusflausino/setup-devops-cli/internal/utils"
+)
+
+// apt gerencia pacotes no Ubuntu e derivados
+type apt struct{}
+
+func (a *apt) Name() string {
+	return "apt"
+}
+
+func (a *apt) Update() error {
+	if err := runManager("apt", "apt-get", "update"); err != nil {
+		return fmt.Errorf("erro ao atualizar repositórios: %w", err)
+	}
+	return nil
+}
+
+func (a *apt) Install(pkgs ...string) error {
+	return runManager("apt", "apt-get", append([]string{"install", "-y"}, pkgs...)...)
+}
+
+func (a *apt) Remove(pkgs ...string) error {
+	return runManager("apt", "apt-get", append([]string{"remove", "-y"}, pkgs...)...)
+}
+
+func (a *apt) IsInstalled(pkg string) bool {
+	status, err := utils.RunCommandOutput("dpkg-query", "-W", "-f=${Status}", pkg)
+	return err == nil && strings.Contains(status, "install ok installed")
+}
+
+func (a *apt) InstalledVersion(pkg string) (string, error) {
+	if !a.IsInstalled(pkg) {
+		return "", fmt.Errorf("pacote %s não está instalado", pkg)
+	}
+	version, err := utils.RunCommandOutput("dpkg-query", "-W", "-f=${Version}", pkg)
+	return strings.TrimSpace(version), err
+}
+
+// Locais usados pelo apt para repositórios de terceiros
+const (
+	aptKeyringsDir = "/etc/apt/keyrings"
+	aptSourcesDir  = "/etc/apt/sources.list.d"
+)
+
+// AddRepository grava a chave do repositório (verificada e em formato
+// binário) em /etc/apt/keyrings e o repositório no formato deb822 em
+// /etc/apt/sources.list.d/<name>.sources
+func (a *apt) AddRepository(repo Repository) error {
+	if repo.Suite == "" {
+		return fmt.Errorf("não foi possível identificar o codinome da distribuição (VERSION_CODENAME)")
+	}
+
+	// gpg é necessário para verificar e converter a chave
+	if !commandExists("gpg") {
+		if err := RefreshIndex(a); err != nil {
+			return err
+		}
+		if err := a.Install("ca-certificates", "gnupg"); err != nil {
+			return fmt.Errorf("erro ao instalar gnupg: %w", err)
+		}
+	}
+
+	tmpDir, err := os.MkdirTemp("", "setup-devops-key-")
+	if err != nil {
+		return fmt.Errorf("erro ao criar diretório temporário: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	keyFile, repoURL, err := fetchVerifiedKey(repo, tmpDir)
+	if err != nil {
+		return err
+	}
+
+	key, err := dearmorKey(keyFile, tmpDir)
+	if err != nil {
+		return err
+	}
+
+	keyring := filepath.Join(aptKeyringsDir, repo.Name+".gpg")
+	if err := writeRootFile(keyring, string(key), 0o644); err != nil {
+		return err
+	}
+
+	arch, err := utils.RunCommandOutput("dpkg", "--print-architecture")
+	if err != nil {
+		return fmt.Errorf("erro ao obter arquitetura do dpkg: %w", err)
+	}
+
+	sources := fmt.Sprintf("Types: deb\nURIs: %s\nSuites: %s\nComponents: %s\nArchitectures: %s\nSigned-By: %s\n",
+		repoURL, repo.Suite, strings.Join(repo.Components, " "), strings.TrimSpace(arch), keyring)
+	if err := writeRootFile(filepath.Join(aptSourcesDir, repo.Name+".sources"), sources, 0o644); err != nil {
+		return err
+	}
+
+	// Entradas no formato antigo (.list) conflitam com o Signed-By atual
+	return removeLegacyAptFiles(repo)
+}
+
+// RemoveRepository remove o arquivo .sources e a chave do repositório
+func (a *apt) RemoveRepository(repo Repository) error {
+	for _, path := range []string{
+		filepath.Join(aptSourcesDir, repo.Name+".sources"),
+		filepath.Join(aptKeyringsDir, repo.Name+".gpg"),
+	} {
+		if err := removeRootFile(path); err != nil {
+			return err
+		}
+	}
+	return removeLegacyAptFiles(repo)
+}
+
+// removeLegacyAptFiles remove o .list e o keyring em /usr/share/keyrings
+// gravados por versões anteriores
+func removeLegacyAptFiles(repo Repository) error {
+	for _, path := range []string{
+		filepath.Join(aptSourcesDir, repo.Name+".list"),
+		fmt.Sprintf("/usr/share/keyrings/%s-archive-keyring.gpg", repo.Name),
+	} {
+		if err := removeRootFile(path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// InstallFiles instala pacotes .deb locais sem acessar a rede: com uma
+// lista de origens vazia, uma dependência ausente do bundle gera um erro
+// claro em vez de uma tentativa de download
+func (a *apt) InstallFiles(files ...string) error {
+	sourcesDir, err := os.MkdirTemp("", "setup-devops-sources-")
+	if err != nil {
+		return fmt.Errorf("erro ao criar diretório temporário: %w", err)
+	}
+	defer os.RemoveAll(sourcesDir)
+
+	sourceList := filepath.Join(sourcesDir, "sources.list")
+	if err := os.WriteFile(sourceList, nil, 0o644); err != nil {
+		return fmt.Errorf("erro ao criar %s: %w", sourceList, err)
+	}
+
+	args := append([]string{"install", "-y", "--no-download",
+		"-o", "Dir::Etc::SourceList=" + sourceList, "-o", "Dir::Etc::SourceParts=" + sourcesDir}, files...)
+	if err := runManager("apt", "apt-get", args...); err != nil {
+		return fmt.Errorf("instalação sem acesso à rede falhou; verifique se o bundle contém todas as dependências para este sistema: %w", err)
+	}
+	return nil
+}
+
+// Download baixa os pacotes e todas as suas dependências para dir
+func (a *apt) Download(dir string, pkgs ...string) error {
+	args := append([]string{"depends", "--recurse", "--no-recommends", "--no-suggests",
+		"--no-conflicts", "--no-breaks", "--no-replaces", "--no-enhances"}, pkgs...)
+	output, err := utils.RunCommandOutput("apt-cache", args...)
+	if err != nil {
+		return fmt.Errorf("erro ao resolver dependências (o repositório do fornecedor está configurado neste host?): %w", err)
+	}
+
+	return utils.RunCommandInDir(dir, "apt-get", append([]string{"download"}, parseAptDepends(output)...)...)
+}
+
+// parseAptDepends extrai os nomes de pacotes da saída de "apt-cache depends --recurse"
+func parseAptDepends(output string) []string {
+	seen := make(map[string]bool)
+	var pkgs []string
+
+	for _, line := range strings.Split(output, "\n") {
+		// Linhas indentadas descrevem relações; "<pkg>" são pacotes virtuais
+		if line == "" || strings.HasPrefix(line, " ") || strings.HasPrefix(line, "<") {
+			continue
+		}
+		name := strings.TrimSpace(line)
+		if !seen[name] {
+			seen[name] = true
+			pkgs = append(pkgs, name)
+		}
+	}
+	return pkgs
+}
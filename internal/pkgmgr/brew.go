@@ -0,0 +1,65 @@
+package pkgmgr
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/matheusflausino/setup-devops-cli/internal/utils"
+)
+
+// brew gerencia pacotes no macOS com o Homebrew (nunca com sudo)
+type brew struct{}
+
+func (b *brew) Name() string {
+	return "brew"
+}
+
+func (b *brew) Update() error {
+	if !commandExists("brew") {
+		return fmt.Errorf("Homebrew não está instalado. Instale primeiro: https://brew.sh")
+	}
+	if err := utils.RunCommand("brew", "update"); err != nil {
+		return fmt.Errorf("erro ao atualizar o Homebrew: %w", err)
+	}
+	return nil
+}
+
+func (b *brew) Install(pkgs ...string) error {
+	if !commandExists("brew") {
+		return fmt.Errorf("Homebrew não está instalado. Instale primeiro: https://brew.sh")
+	}
+	return utils.RunCommand("brew", append([]string{"install"}, pkgs...)...)
+}
+
+func (b *brew) Remove(pkgs ...string) error {
+	return utils.RunCommand("brew", append([]string{"uninstall"}, pkgs...)...)
+}
+
+func (b *brew) IsInstalled(pkg string) bool {
+	_, err := b.InstalledVersion(pkg)
+	return err == nil
+}
+
+func (b *brew) InstalledVersion(pkg string) (string, error) {
+	// Saída: "<pacote> <versão> [<versão>...]"; a última é a mais recente
+	output, err := utils.RunCommandOutput("brew", "list", "--versions", pkg)
+	fields := strings.Fields(output)
+	if err != nil || len(fields) < 2 {
+		return "", fmt.Errorf("pacote %s não está instalado", pkg)
+	}
+	return fields[len(fields)-1], nil
+}
+
+// AddRepository adiciona um tap do Homebrew (Name no formato usuário/repo)
+func (b *brew) AddRepository(repo Repository) error {
+	args := []string{"tap", repo.Name}
+	if repo.URL != "" {
+		args = append(args, repo.URL)
+	}
+	return utils.RunCommand("brew", args...)
+}
+
+// RemoveRepository remove o tap do Homebrew
+func (b *brew) RemoveRepository(repo Repository) error {
+	return utils.RunCommand("brew", "untap", repo.Name)
+}
@@ -0,0 +1,176 @@
+package pkgmgr
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/matheusflausino/setup-devops-cli/internal/utils"
+)
+
+// fetchVerifiedKey baixa a chave do repositório e confere o fingerprint com
+// os valores fixados em repo.Fingerprints. Retorna o caminho da chave e a URL
+// do repositório reescrita para a origem que respondeu.
+func fetchVerifiedKey(repo Repository, dir string) (string, string, error) {
+	keyFile, repoURL, err := fetchKey(repo, dir)
+	if err != nil {
+		return "", "", err
+	}
+
+	if err := VerifyKey(repo, keyFile); err != nil {
+		return "", "", err
+	}
+	return keyFile, repoURL, nil
+}
+
+// VerifyKey garante que todas as chaves primárias contidas em keyFile têm um
+// dos fingerprints fixados, de forma que uma chave extra injetada no arquivo
+// também seja rejeitada
+func VerifyKey(repo Repository, keyFile string) error {
+	if len(repo.Fingerprints) == 0 {
+		color.Yellow("⚠️  Nenhum fingerprint fixado para a chave de %s; a chave não foi verificada", repo.Name)
+		return nil
+	}
+
+	fingerprints, err := keyFingerprints(keyFile)
+	if err != nil {
+		return fmt.Errorf("erro ao ler a chave de %s: %w", repo.Name, err)
+	}
+	if len(fingerprints) == 0 {
+		return fmt.Errorf("nenhuma chave encontrada em %s", repo.KeyURL)
+	}
+
+	pinned := make(map[string]bool)
+	for _, fpr := range repo.Fingerprints {
+		pinned[normalizeFingerprint(fpr)] = true
+	}
+
+	for _, fpr := range fingerprints {
+		if !pinned[fpr] {
+			return fmt.Errorf("fingerprint da chave de %s não confere: obtido %s, esperado %s",
+				repo.Name, fpr, strings.Join(repo.Fingerprints, " ou "))
+		}
+	}
+	return nil
+}
+
+// keyFingerprints retorna os fingerprints das chaves primárias em keyFile,
+// sem importá-las em nenhum keyring. Chaves RSA em PEM (apk) são
+// identificadas pelo SHA-256 da chave pública.
+func keyFingerprints(keyFile string) ([]string, error) {
+	data, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, err
+	}
+	if bytes.Contains(data, []byte("-----BEGIN PUBLIC KEY-----")) {
+		return publicKeyFingerprints(data)
+	}
+
+	home, err := os.MkdirTemp("", "setup-devops-gnupg-")
+	if err != nil {
+		return nil, fmt.Errorf("erro ao criar diretório temporário: %w", err)
+	}
+	defer os.RemoveAll(home)
+
+	output, err := utils.RunCommandOutput("gpg", "--homedir", home, "--batch", "--with-colons", "--show-keys", keyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	// Registros no formato --with-colons: o "fpr" logo após um "pub" é o da
+	// chave primária; os que seguem um "sub" são das subchaves
+	var fingerprints []string
+	primary := false
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Split(line, ":")
+		switch fields[0] {
+		case "pub":
+			primary = true
+		case "sub":
+			primary = false
+		case "fpr":
+			if primary && len(fields) > 9 {
+				fingerprints = append(fingerprints, normalizeFingerprint(fields[9]))
+				primary = false
+			}
+		}
+	}
+	return fingerprints, nil
+}
+
+// publicKeyFingerprints retorna o SHA-256 (em hexadecimal) de cada chave
+// pública em PEM, o mesmo valor de "openssl pkey -pubin -outform DER | sha256sum"
+func publicKeyFingerprints(data []byte) ([]string, error) {
+	var fingerprints []string
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return fingerprints, nil
+		}
+		if block.Type != "PUBLIC KEY" {
+			continue
+		}
+		if _, err := x509.ParsePKIXPublicKey(block.Bytes); err != nil {
+			return nil, err
+		}
+		sum := sha256.Sum256(block.Bytes)
+		fingerprints = append(fingerprints, normalizeFingerprint(hex.EncodeToString(sum[:])))
+	}
+}
+
+// normalizeFingerprint remove espaços e padroniza o fingerprint em maiúsculas
+func normalizeFingerprint(fpr string) string {
+	return strings.ToUpper(strings.ReplaceAll(fpr, " ", ""))
+}
+
+// dearmorKey converte a chave para o formato binário aceito pelo Signed-By do
+// apt; chaves que já estão em formato binário são mantidas
+func dearmorKey(keyFile, dir string) ([]byte, error) {
+	data, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Contains(data, []byte("-----BEGIN PGP PUBLIC KEY BLOCK-----")) {
+		return data, nil
+	}
+
+	dearmored := filepath.Join(dir, filepath.Base(keyFile)+".gpg")
+	if err := utils.RunCommand("gpg", "--batch", "--yes", "--dearmor", "-o", dearmored, keyFile); err != nil {
+		return nil, fmt.Errorf("erro ao converter a chave GPG: %w", err)
+	}
+	return os.ReadFile(dearmored)
+}
+
+// VerifySignature confere a assinatura GPG destacada sigFile de dataFile
+// com a chave keyFile, depois de garantir que a chave tem um dos
+// fingerprints fixados. A chave é importada em um keyring temporário.
+func VerifySignature(name, keyFile, sigFile, dataFile string, fingerprints []string) error {
+	if len(fingerprints) == 0 {
+		return fmt.Errorf("nenhum fingerprint fixado para a chave de %s", name)
+	}
+	if err := VerifyKey(Repository{Name: name, KeyURL: keyFile, Fingerprints: fingerprints}, keyFile); err != nil {
+		return err
+	}
+
+	home, err := os.MkdirTemp("", "setup-devops-gnupg-")
+	if err != nil {
+		return fmt.Errorf("erro ao criar diretório temporário: %w", err)
+	}
+	defer os.RemoveAll(home)
+
+	if output, err := utils.RunCommandCapture("gpg", "--homedir", home, "--batch", "--import", keyFile); err != nil {
+		return fmt.Errorf("erro ao importar a chave GPG de %s: %s", name, utils.LastLines(output))
+	}
+	if output, err := utils.RunCommandCapture("gpg", "--homedir", home, "--batch", "--verify", sigFile, dataFile); err != nil {
+		return fmt.Errorf("assinatura inválida para %s: %s", filepath.Base(dataFile), utils.LastLines(output))
+	}
+	return nil
+}
@@ -0,0 +1,248 @@
+package pkgmgr
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/matheusflausino/setup-devops-cli/internal/config"
+	"github.com/matheusflausino/setup-devops-cli/internal/privilege"
+)
+
+// lockSpec descreve como cada gerenciador de pacotes sinaliza que está em uso
+type lockSpec struct {
+	// Files são travados com flock/fcntl enquanto o gerenciador roda
+	// (verificados em /proc/locks)
+	Files []string
+	// PIDFiles contêm o PID do processo que segura o lock
+	PIDFiles []string
+	// Markers existem apenas enquanto o lock está ativo
+	Markers []string
+	// Messages são trechos da saída que indicam que o lock não foi obtido
+	Messages []string
+}
+
+var rpmLock = lockSpec{
+	Files:    []string{"/var/lib/rpm/.rpm.lock"},
+	PIDFiles: []string{"/var/run/yum.pid", "/var/run/dnf/rpmdb_lock.pid", "/var/cache/dnf/metadata_lock.pid"},
+	Messages: []string{"Another app is currently holding the yum lock", "Waiting for process with pid", "Failed to obtain rpm transaction lock", "can't create transaction lock"},
+}
+
+// Locks de cada gerenciador de pacotes, pelo nome
+var managerLocks = map[string]lockSpec{
+	"apt": {
+		Files:    []string{"/var/lib/dpkg/lock-frontend", "/var/lib/dpkg/lock", "/var/lib/apt/lists/lock", "/var/cache/apt/archives/lock"},
+		Messages: []string{"Could not get lock", "Unable to acquire the dpkg frontend lock", "Unable to lock directory"},
+	},
+	"dnf": rpmLock,
+	"yum": rpmLock,
+	"rpm": rpmLock,
+	"zypper": {
+		Files:    rpmLock.Files,
+		PIDFiles: []string{"/run/zypp.pid", "/var/run/zypp.pid"},
+		Messages: []string{"System management is locked"},
+	},
+	"pacman": {
+		Markers:  []string{"/var/lib/pacman/db.lck"},
+		Messages: []string{"unable to lock database"},
+	},
+	"apk": {
+		Files:    []string{"/lib/apk/db/lock"},
+		Messages: []string{"Unable to lock database", "unable to lock database"},
+	},
+}
+
+// Trechos da saída que indicam falhas transitórias (rede, mirror em
+// sincronização), que costumam ser resolvidas repetindo o comando
+var transientMessages = []string{
+	"Hash Sum mismatch",
+	"Mirror sync in progress",
+	"Failed to fetch",
+	"Unable to fetch some archives",
+	"Temporary failure resolving",
+	"Could not resolve",
+	"Connection timed out",
+	"Connection failed",
+	"Cannot download repomd.xml",
+	"Failed to download metadata",
+	"Curl error",
+	"All mirrors were tried",
+	"Download (curl) error",
+	"failed retrieving file",
+	"temporary error",
+}
+
+// runManager executa um comando privilegiado do gerenciador de pacotes.
+// Se outro processo (ex: unattended-upgrades) segura o lock, aguarda com uma
+// contagem regressiva até config.LockTimeout(); falhas transitórias são
+// repetidas até config.PackageRetries() vezes, com espera crescente.
+func runManager(manager, name string, args ...string) error {
+	spec := managerLocks[manager]
+	deadline := time.Now().Add(config.LockTimeout())
+	backoff := 5 * time.Second
+	retries := 0
+
+	for {
+		if err := waitForLock(manager, spec, deadline); err != nil {
+			return err
+		}
+
+		output, err := privilege.RunCapture(name, args...)
+		if err == nil {
+			return nil
+		}
+
+		switch {
+		case containsAny(output, spec.Messages) && time.Now().Before(deadline):
+			// Outro processo obteve o lock entre a verificação e a execução
+			color.Yellow("⚠️  %s está em uso por outro processo", manager)
+			sleepWithCountdown(fmt.Sprintf("Nova tentativa de %s", name), backoff)
+		case containsAny(output, transientMessages) && retries < config.PackageRetries():
+			retries++
+			color.Yellow("⚠️  Falha transitória em %s (%s); tentativa %d de %d",
+				name, lastLine(output), retries, config.PackageRetries())
+			sleepWithCountdown(fmt.Sprintf("Nova tentativa de %s", name), backoff)
+			backoff *= 2
+		default:
+			if line := lastLine(output); line != "" {
+				return fmt.Errorf("%w: %s", err, line)
+			}
+			return err
+		}
+	}
+}
+
+// waitForLock aguarda, com contagem regressiva, até que o lock do
+// gerenciador esteja livre ou o prazo termine
+func waitForLock(manager string, spec lockSpec, deadline time.Time) error {
+	holder, locked := lockHolder(spec)
+	if !locked {
+		return nil
+	}
+
+	for locked {
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			fmt.Println()
+			return fmt.Errorf("o lock de %s continua em uso por %s após %s; aumente o limite com --lock-timeout ou packages.lockTimeout",
+				manager, holder, config.LockTimeout())
+		}
+
+		fmt.Printf("\r⏳ %s está em uso por %s; aguardando (%s restantes)...   ", manager, holder, remaining.Round(time.Second))
+		time.Sleep(time.Second)
+		holder, locked = lockHolder(spec)
+	}
+
+	fmt.Println()
+	color.Green("✅ Lock de %s liberado", manager)
+	return nil
+}
+
+// sleepWithCountdown aguarda d mostrando o tempo restante
+func sleepWithCountdown(message string, d time.Duration) {
+	for remaining := d; remaining > 0; remaining -= time.Second {
+		fmt.Printf("\r⏳ %s em %s...   ", message, remaining)
+		time.Sleep(time.Second)
+	}
+	fmt.Println()
+}
+
+// lockHolder verifica se o lock está em uso e descreve o processo que o segura
+func lockHolder(spec lockSpec) (string, bool) {
+	for _, path := range spec.Markers {
+		if _, err := os.Stat(path); err == nil {
+			return path, true
+		}
+	}
+
+	for _, path := range spec.PIDFiles {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		if pid, err := strconv.Atoi(strings.TrimSpace(string(data))); err == nil && processExists(pid) {
+			return describeProcess(pid), true
+		}
+	}
+
+	if pid, ok := flockHolder(spec.Files); ok {
+		return describeProcess(pid), true
+	}
+	return "", false
+}
+
+// flockHolder procura em /proc/locks um lock sobre algum dos arquivos e
+// retorna o PID do processo que o segura. Os arquivos são comparados pelo
+// inode, já que /proc/locks não informa caminhos.
+func flockHolder(files []string) (int, bool) {
+	inodes := make(map[string]bool)
+	for _, path := range files {
+		var st syscall.Stat_t
+		if err := syscall.Stat(path, &st); err == nil {
+			inodes[strconv.FormatUint(uint64(st.Ino), 10)] = true
+		}
+	}
+	if len(inodes) == 0 {
+		return 0, false
+	}
+
+	f, err := os.Open("/proc/locks")
+	if err != nil {
+		return 0, false
+	}
+	defer f.Close()
+
+	// Formato: "1: POSIX  ADVISORY  WRITE 1234 08:01:131090 0 EOF"
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 6 || fields[1] == "->" {
+			continue
+		}
+		device := strings.Split(fields[5], ":")
+		if !inodes[device[len(device)-1]] {
+			continue
+		}
+		if pid, err := strconv.Atoi(fields[4]); err == nil && pid != os.Getpid() {
+			return pid, true
+		}
+	}
+	return 0, false
+}
+
+// processExists verifica se o processo ainda está rodando
+func processExists(pid int) bool {
+	_, err := os.Stat(filepath.Join("/proc", strconv.Itoa(pid)))
+	return err == nil
+}
+
+// describeProcess retorna o nome e o PID do processo (ex: "unattended-upgr (pid 812)")
+func describeProcess(pid int) string {
+	comm, err := os.ReadFile(filepath.Join("/proc", strconv.Itoa(pid), "comm"))
+	if err != nil {
+		return fmt.Sprintf("pid %d", pid)
+	}
+	return fmt.Sprintf("%s (pid %d)", strings.TrimSpace(string(comm)), pid)
+}
+
+// containsAny verifica se a saída contém algum dos trechos
+func containsAny(output string, messages []string) bool {
+	for _, message := range messages {
+		if strings.Contains(output, message) {
+			return true
+		}
+	}
+	return false
+}
+
+// lastLine retorna a última linha não vazia da saída, usada nas mensagens de erro
+func lastLine(output string) string {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
@@ -0,0 +1,63 @@
+package pkgmgr
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/matheusflausino/setup-devops-cli/internal/utils"
+)
+
+// pacman gerencia pacotes no Arch Linux e derivados
+type pacman struct{}
+
+func (p *pacman) Name() string {
+	return "pacman"
+}
+
+func (p *pacman) Update() error {
+	if err := runManager("pacman", "pacman", "-Sy", "--noconfirm"); err != nil {
+		return fmt.Errorf("erro ao atualizar repositórios: %w", err)
+	}
+	return nil
+}
+
+func (p *pacman) Install(pkgs ...string) error {
+	return runManager("pacman", "pacman", append([]string{"-S", "--needed", "--noconfirm"}, pkgs...)...)
+}
+
+func (p *pacman) Remove(pkgs ...string) error {
+	return runManager("pacman", "pacman", append([]string{"-R", "--noconfirm"}, pkgs...)...)
+}
+
+func (p *pacman) IsInstalled(pkg string) bool {
+	_, err := utils.RunCommandOutput("pacman", "-Q", pkg)
+	return err == nil
+}
+
+func (p *pacman) InstalledVersion(pkg string) (string, error) {
+	output, err := utils.RunCommandOutput("pacman", "-Q", pkg)
+	if err != nil {
+		return "", fmt.Errorf("pacote %s não está instalado", pkg)
+	}
+
+	// Saída: "<pacote> <versão>"
+	fields := strings.Fields(output)
+	if len(fields) < 2 {
+		return "", fmt.Errorf("saída inesperada do pacman: %q", output)
+	}
+	return fields[1], nil
+}
+
+// AddRepository não é suportado: os repositórios oficiais do Arch já
+// empacotam as ferramentas e repositórios extras exigem editar pacman.conf
+func (p *pacman) AddRepository(repo Repository) error {
+	return fmt.Errorf("repositórios de terceiros não são suportados no pacman (%s)", repo.Name)
+}
+
+func (p *pacman) RemoveRepository(repo Repository) error {
+	return fmt.Errorf("repositórios de terceiros não são suportados no pacman (%s)", repo.Name)
+}
+
+func (p *pacman) InstallFiles(files ...string) error {
+	return runManager("pacman", "pacman", append([]string{"-U", "--needed", "--noconfirm"}, files...)...)
+}
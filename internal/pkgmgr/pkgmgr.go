@@ -0,0 +1,196 @@
+// Package pkgmgr abstrai os gerenciadores de pacotes do sistema (apt, dnf,
+// yum, zypper, pacman, apk e Homebrew), de forma que os instaladores
+// declarem apenas quais pacotes precisam e funcionem em qualquer sistema.
+package pkgmgr
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/matheusflausino/setup-devops-cli/internal/privilege"
+	"github.com/matheusflausino/setup-devops-cli/internal/utils"
+)
+
+// PackageManager é a interface comum a todos os gerenciadores de pacotes
+type PackageManager interface {
+	// Name retorna o nome do gerenciador (apt, dnf, yum, zypper, pacman, apk, brew)
+	Name() string
+	// Update atualiza o índice de pacotes
+	Update() error
+	// Install instala os pacotes informados
+	Install(pkgs ...string) error
+	// Remove remove os pacotes informados
+	Remove(pkgs ...string) error
+	// IsInstalled verifica se o pacote está instalado
+	IsInstalled(pkg string) bool
+	// InstalledVersion retorna a versão instalada do pacote
+	InstalledVersion(pkg string) (string, error)
+	// AddRepository configura um repositório de terceiros. Executar novamente
+	// com o mesmo repositório não altera nenhum arquivo.
+	AddRepository(repo Repository) error
+	// RemoveRepository remove a configuração e a chave de um repositório
+	RemoveRepository(repo Repository) error
+}
+
+// LocalInstaller é implementado pelos gerenciadores que instalam arquivos
+// de pacote locais (.deb/.rpm), usado na instalação offline
+type LocalInstaller interface {
+	InstallFiles(files ...string) error
+}
+
+// Downloader é implementado pelos gerenciadores que baixam pacotes e todas
+// as suas dependências sem instalá-los, usado na criação de bundles
+type Downloader interface {
+	Download(dir string, pkgs ...string) error
+}
+
+// KeyImporter é implementado pelos gerenciadores que verificam a assinatura
+// de pacotes locais com chaves importadas (rpm e apk)
+type KeyImporter interface {
+	ImportKey(path string) error
+}
+
+// Repository descreve um repositório de pacotes de terceiros
+type Repository struct {
+	// Name identifica o repositório (nome dos arquivos de configuração e da chave)
+	Name string
+	// Tool é a ferramenta dona do repositório, usada para aplicar os mirrors
+	Tool string
+	// URL é a URL base do repositório
+	URL string
+	// KeyURL é a URL da chave GPG que assina o repositório
+	KeyURL string
+	// Fingerprints são os fingerprints aceitos para a chave (chave atual e,
+	// durante uma rotação, a nova); a chave baixada é rejeitada se não conferir.
+	// Para as chaves RSA do apk, é o SHA-256 da chave pública em DER.
+	Fingerprints []string
+	// Suite e Components descrevem o repositório apt (ex: jammy, [stable])
+	Suite      string
+	Components []string
+	// RepoFileURL é um arquivo .repo pronto publicado pelo fornecedor (dnf, yum, zypper)
+	RepoFileURL string
+}
+
+// current é o gerenciador de pacotes da sessão, escolhido uma única vez
+var current PackageManager
+
+// indexFresh indica que o índice de pacotes já foi atualizado nesta sessão e
+// nenhum repositório foi alterado desde então
+var indexFresh bool
+
+// RefreshIndex atualiza o índice de pacotes apenas na primeira vez na sessão
+// ou quando um repositório foi adicionado ou removido desde a última atualização
+func RefreshIndex(pm PackageManager) error {
+	if indexFresh {
+		return nil
+	}
+
+	if err := pm.Update(); err != nil {
+		return err
+	}
+
+	indexFresh = true
+	return nil
+}
+
+// ForOS retorna o gerenciador de pacotes adequado ao sistema detectado
+func ForOS(osInfo *utils.OSInfo) (PackageManager, error) {
+	if current != nil {
+		return current, nil
+	}
+
+	pm, err := New(NameFor(osInfo))
+	if err != nil {
+		return nil, err
+	}
+
+	current = pm
+	return pm, nil
+}
+
+// NameFor retorna o nome do gerenciador de pacotes usado pelo sistema
+func NameFor(osInfo *utils.OSInfo) string {
+	switch osInfo.Type {
+	case utils.Ubuntu, utils.Debian:
+		return "apt"
+	case utils.CentOS, utils.Fedora, utils.AmazonLinux:
+		// RHEL 8+, Fedora e Amazon Linux 2023 usam dnf; yum fica como
+		// alternativa em sistemas sem ele (Amazon Linux 2)
+		if commandExists("dnf") || !commandExists("yum") {
+			return "dnf"
+		}
+		return "yum"
+	case utils.OpenSUSE:
+		return "zypper"
+	case utils.Arch:
+		return "pacman"
+	case utils.Alpine:
+		return "apk"
+	case utils.MacOS:
+		return "brew"
+	default:
+		return ""
+	}
+}
+
+// New cria o gerenciador de pacotes pelo nome
+func New(name string) (PackageManager, error) {
+	switch name {
+	case "apt":
+		return &apt{}, nil
+	case "dnf":
+		return &rpmManager{name: "dnf", reposDir: "/etc/yum.repos.d"}, nil
+	case "yum":
+		return &rpmManager{name: "yum", reposDir: "/etc/yum.repos.d"}, nil
+	case "zypper":
+		return &zypper{}, nil
+	case "pacman":
+		return &pacman{}, nil
+	case "apk":
+		return &apk{}, nil
+	case "brew":
+		return &brew{}, nil
+	default:
+		return nil, fmt.Errorf("gerenciador de pacotes não suportado: %q", name)
+	}
+}
+
+// commandExists verifica se um comando está disponível no PATH
+func commandExists(command string) bool {
+	_, err := exec.LookPath(command)
+	return err == nil
+}
+
+// sudo executa um comando com privilégios de administrador
+func sudo(name string, args ...string) error {
+	return privilege.Run(name, args...)
+}
+
+// writeRootFile grava content em path (um arquivo de configuração de
+// repositório) com o modo informado. Se o arquivo já tiver o mesmo conteúdo e
+// modo, nada é alterado; caso contrário o índice de pacotes fica desatualizado.
+func writeRootFile(path, content string, mode os.FileMode) error {
+	changed, err := privilege.WriteFile(path, content, mode)
+	if err != nil {
+		return err
+	}
+
+	if changed {
+		indexFresh = false
+	}
+	return nil
+}
+
+// removeRootFile remove um arquivo de configuração de repositório, se existir
+func removeRootFile(path string) error {
+	if _, err := os.Lstat(path); os.IsNotExist(err) {
+		return nil
+	}
+	if err := sudo("rm", "-f", path); err != nil {
+		return fmt.Errorf("erro ao remover %s: %w", path, err)
+	}
+
+	indexFresh = false
+	return nil
+}
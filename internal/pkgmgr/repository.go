@@ -0,0 +1,82 @@
+package pkgmgr
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/matheusflausino/setup-devops-cli/internal/download"
+)
+
+// fetchKey baixa a chave GPG do repositório (respeitando os mirrors da
+// ferramenta) para dir e retorna o caminho do arquivo e a URL do
+// repositório reescrita para a origem que respondeu
+func fetchKey(repo Repository, dir string) (string, string, error) {
+	keyFile := filepath.Join(dir, repo.Name+".asc")
+	base, err := download.FetchWithBase(repo.Tool, repo.KeyURL, keyFile)
+	if err != nil {
+		return "", "", fmt.Errorf("erro ao baixar chave GPG de %s: %w", repo.Name, err)
+	}
+
+	origin, err := download.Origin(repo.KeyURL)
+	if err != nil {
+		return "", "", err
+	}
+	return keyFile, download.Rewrite(repo.URL, origin, base), nil
+}
+
+// fetchRepoFile baixa o arquivo .repo publicado pelo fornecedor (respeitando
+// os mirrors da ferramenta) e reescreve as URLs de baseurl/gpgkey para a
+// origem que respondeu
+func fetchRepoFile(repo Repository) (string, error) {
+	tmpDir, err := os.MkdirTemp("", "setup-devops-repo-")
+	if err != nil {
+		return "", fmt.Errorf("erro ao criar diretório temporário: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	repoFile := filepath.Join(tmpDir, repo.Name+".repo")
+	base, err := download.FetchWithBase(repo.Tool, repo.RepoFileURL, repoFile)
+	if err != nil {
+		return "", fmt.Errorf("erro ao baixar %s: %w", repo.RepoFileURL, err)
+	}
+
+	content, err := os.ReadFile(repoFile)
+	if err != nil {
+		return "", fmt.Errorf("erro ao ler %s: %w", repoFile, err)
+	}
+
+	origin, err := download.Origin(repo.RepoFileURL)
+	if err != nil {
+		return "", err
+	}
+	return download.Rewrite(string(content), origin, base), nil
+}
+
+// rpmRepoFile retorna o conteúdo de um arquivo .repo para o repositório,
+// baixando o arquivo do fornecedor quando houver um ou gerando a partir da URL
+func rpmRepoFile(repo Repository) (string, error) {
+	if repo.RepoFileURL != "" {
+		return fetchRepoFile(repo)
+	}
+
+	content := fmt.Sprintf("[%s]\nname=%s\nbaseurl=%s\nenabled=1\n", repo.Name, repo.Name, repo.URL)
+	if repo.KeyURL != "" {
+		content += fmt.Sprintf("gpgcheck=1\ngpgkey=%s\n", repo.KeyURL)
+	}
+
+	if repo.Tool == "" {
+		return content, nil
+	}
+
+	// Aplicar o primeiro mirror configurado para a ferramenta
+	origin, err := download.Origin(repo.URL)
+	if err != nil {
+		return "", err
+	}
+	bases, err := download.Bases(repo.Tool, repo.URL)
+	if err != nil {
+		return "", err
+	}
+	return download.Rewrite(content, origin, bases[0]), nil
+}
@@ -0,0 +1,160 @@
+package pkgmgr
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/matheusflausino/setup-devops-cli/internal/utils"
+)
+
+// rpmManager gerencia pacotes com dnf ou yum (CentOS, RHEL e derivados)
+type rpmManager struct {
+	name     string
+	reposDir string
+}
+
+func (r *rpmManager) Name() string {
+	return r.name
+}
+
+func (r *rpmManager) Update() error {
+	if err := runManager(r.name, r.name, "makecache"); err != nil {
+		return fmt.Errorf("erro ao atualizar repositórios: %w", err)
+	}
+	return nil
+}
+
+func (r *rpmManager) Install(pkgs ...string) error {
+	return runManager(r.name, r.name, append([]string{"install", "-y"}, pkgs...)...)
+}
+
+func (r *rpmManager) Remove(pkgs ...string) error {
+	return runManager(r.name, r.name, append([]string{"remove", "-y"}, pkgs...)...)
+}
+
+func (r *rpmManager) IsInstalled(pkg string) bool {
+	return rpmIsInstalled(pkg)
+}
+
+func (r *rpmManager) InstalledVersion(pkg string) (string, error) {
+	return rpmInstalledVersion(pkg)
+}
+
+// AddRepository importa a chave verificada do repositório e grava o arquivo
+// .repo em /etc/yum.repos.d
+func (r *rpmManager) AddRepository(repo Repository) error {
+	return addRPMRepository(repo, r.reposDir)
+}
+
+// RemoveRepository remove o arquivo .repo e a chave importada
+func (r *rpmManager) RemoveRepository(repo Repository) error {
+	return removeRPMRepository(repo, r.reposDir)
+}
+
+// InstallFiles instala pacotes .rpm locais sem consultar os repositórios,
+// verificando antes as assinaturas com as chaves importadas
+func (r *rpmManager) InstallFiles(files ...string) error {
+	if err := rpmCheckSig(files); err != nil {
+		return err
+	}
+	return runManager(r.name, r.name, append([]string{"install", "-y", "--disablerepo=*"}, files...)...)
+}
+
+func (r *rpmManager) ImportKey(path string) error {
+	return rpmImportKey(path)
+}
+
+// Download baixa os pacotes e todas as suas dependências para dir
+func (r *rpmManager) Download(dir string, pkgs ...string) error {
+	if r.name == "dnf" {
+		return utils.RunCommand("dnf", append([]string{"download", "--resolve", "--alldeps", "--destdir", dir}, pkgs...)...)
+	}
+	return utils.RunCommand("yumdownloader", append([]string{"--resolve", "--destdir", dir}, pkgs...)...)
+}
+
+// addRPMRepository importa a chave do repositório, após conferir o
+// fingerprint, e grava o arquivo .repo em reposDir
+func addRPMRepository(repo Repository, reposDir string) error {
+	if repo.KeyURL != "" {
+		tmpDir, err := os.MkdirTemp("", "setup-devops-key-")
+		if err != nil {
+			return fmt.Errorf("erro ao criar diretório temporário: %w", err)
+		}
+		defer os.RemoveAll(tmpDir)
+
+		keyFile, _, err := fetchVerifiedKey(repo, tmpDir)
+		if err != nil {
+			return err
+		}
+
+		// Importar a chave verificada evita que o dnf/yum confie na chave
+		// baixada por ele mesmo a partir do gpgkey do .repo
+		if err := rpmImportKey(keyFile); err != nil {
+			return err
+		}
+	}
+
+	content, err := rpmRepoFile(repo)
+	if err != nil {
+		return err
+	}
+	return writeRootFile(filepath.Join(reposDir, repo.Name+".repo"), content, 0o644)
+}
+
+// removeRPMRepository remove o arquivo .repo de reposDir e as chaves fixadas
+// do repositório da base do rpm
+func removeRPMRepository(repo Repository, reposDir string) error {
+	if err := removeRootFile(filepath.Join(reposDir, repo.Name+".repo")); err != nil {
+		return err
+	}
+
+	// As chaves ficam na base do rpm como gpg-pubkey-<últimos 8 dígitos do fingerprint>
+	for _, fpr := range repo.Fingerprints {
+		fpr = normalizeFingerprint(fpr)
+		if len(fpr) < 8 {
+			continue
+		}
+		pkg := "gpg-pubkey-" + strings.ToLower(fpr[len(fpr)-8:])
+		if !rpmIsInstalled(pkg) {
+			continue
+		}
+		if err := runManager("rpm", "rpm", "-e", "--allmatches", pkg); err != nil {
+			return fmt.Errorf("erro ao remover chave %s: %w", pkg, err)
+		}
+	}
+	return nil
+}
+
+// rpmIsInstalled verifica na base do rpm se o pacote está instalado
+func rpmIsInstalled(pkg string) bool {
+	_, err := utils.RunCommandOutput("rpm", "-q", pkg)
+	return err == nil
+}
+
+// rpmInstalledVersion retorna a versão instalada do pacote segundo a base do rpm
+func rpmInstalledVersion(pkg string) (string, error) {
+	version, err := utils.RunCommandOutput("rpm", "-q", "--qf", "%{VERSION}-%{RELEASE}", pkg)
+	if err != nil {
+		return "", fmt.Errorf("pacote %s não está instalado", pkg)
+	}
+	return strings.TrimSpace(version), nil
+}
+
+// rpmCheckSig confere as assinaturas dos pacotes .rpm com as chaves
+// importadas na base do rpm
+func rpmCheckSig(files []string) error {
+	if err := utils.RunCommand("rpm", append([]string{"--checksig"}, files...)...); err != nil {
+		return fmt.Errorf("assinatura inválida nos pacotes: %w", err)
+	}
+	return nil
+}
+
+// rpmImportKey importa uma chave GPG na base do rpm
+func rpmImportKey(path string) error {
+	if err := runManager("rpm", "rpm", "--import", path); err != nil {
+		return fmt.Errorf("erro ao importar chave GPG: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package pkgmgr
+
+import (
+	"fmt"
+)
+
+// zypperReposDir é onde o zypper lê os arquivos .repo
+const zypperReposDir = "/etc/zypp/repos.d"
+
+// zypper gerencia pacotes no openSUSE e SLES
+type zypper struct{}
+
+func (z *zypper) Name() string {
+	return "zypper"
+}
+
+// Update atualiza os repositórios sem --gpg-auto-import-keys: as chaves são
+// importadas por AddRepository, após conferir o fingerprint
+func (z *zypper) Update() error {
+	if err := runManager("zypper", "zypper", "--non-interactive", "refresh"); err != nil {
+		return fmt.Errorf("erro ao atualizar repositórios: %w", err)
+	}
+	return nil
+}
+
+func (z *zypper) Install(pkgs ...string) error {
+	return runManager("zypper", "zypper", append([]string{"--non-interactive", "install"}, pkgs...)...)
+}
+
+func (z *zypper) Remove(pkgs ...string) error {
+	return runManager("zypper", "zypper", append([]string{"--non-interactive", "remove"}, pkgs...)...)
+}
+
+func (z *zypper) IsInstalled(pkg string) bool {
+	return rpmIsInstalled(pkg)
+}
+
+func (z *zypper) InstalledVersion(pkg string) (string, error) {
+	return rpmInstalledVersion(pkg)
+}
+
+// AddRepository importa a chave verificada e grava o arquivo .repo em
+// /etc/zypp/repos.d, no mesmo formato usado pelo dnf/yum
+func (z *zypper) AddRepository(repo Repository) error {
+	return addRPMRepository(repo, zypperReposDir)
+}
+
+// RemoveRepository remove o arquivo .repo e a chave importada
+func (z *zypper) RemoveRepository(repo Repository) error {
+	return removeRPMRepository(repo, zypperReposDir)
+}
+
+// InstallFiles instala pacotes .rpm locais sem atualizar os repositórios,
+// verificando antes as assinaturas com as chaves importadas
+func (z *zypper) InstallFiles(files ...string) error {
+	if err := rpmCheckSig(files); err != nil {
+		return err
+	}
+	return runManager("zypper", "zypper", append([]string{"--non-interactive", "--no-refresh", "install"}, files...)...)
+}
+
+func (z *zypper) ImportKey(path string) error {
+	return rpmImportKey(path)
+}
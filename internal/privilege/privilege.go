@@ -0,0 +1,215 @@
+// Package privilege abstrai a elevação de privilégios (sudo, doas ou
+// nenhuma) usada nas etapas que alteram o sistema, como instalar pacotes e
+// gravar arquivos de configuração.
+package privilege
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/matheusflausino/setup-devops-cli/internal/config"
+	"github.com/matheusflausino/setup-devops-cli/internal/utils"
+)
+
+// Strategy é a forma de executar comandos como administrador
+type Strategy string
+
+const (
+	// Sudo executa os comandos com sudo
+	Sudo Strategy = "sudo"
+	// Doas executa os comandos com doas (OpenBSD, Alpine, Void)
+	Doas Strategy = "doas"
+	// None executa os comandos diretamente (já como root, ex: em containers)
+	None Strategy = "none"
+)
+
+// keepAliveInterval é o intervalo de renovação das credenciais do sudo,
+// menor que o timestamp_timeout padrão de 5 minutos
+const keepAliveInterval = time.Minute
+
+var (
+	detectOnce sync.Once
+	current    Strategy
+	keepAlive  sync.Once
+
+	// validated indica que as credenciais já foram validadas nesta execução
+	validated bool
+	// announced são os comandos já listados por Prepare
+	announced = make(map[string]bool)
+)
+
+// Current retorna a estratégia da sessão, detectada uma única vez: a
+// configurada em --escalation ou, em modo auto, nenhuma como root, sudo se
+// disponível e doas caso contrário
+func Current() Strategy {
+	detectOnce.Do(func() {
+		current = detect()
+	})
+	return current
+}
+
+func detect() Strategy {
+	switch Strategy(config.Escalation()) {
+	case Sudo:
+		return Sudo
+	case Doas:
+		return Doas
+	case None:
+		return None
+	}
+
+	if utils.IsRoot() {
+		return None
+	}
+	if _, err := exec.LookPath("sudo"); err == nil {
+		return Sudo
+	}
+	if _, err := exec.LookPath("doas"); err == nil {
+		return Doas
+	}
+	return Sudo
+}
+
+// Command retorna o comando e os argumentos para executar name como
+// administrador com a estratégia da sessão
+func Command(name string, args ...string) (string, []string) {
+	strategy := Current()
+	if strategy == None {
+		return name, args
+	}
+	return string(strategy), append([]string{name}, args...)
+}
+
+// Run executa um comando como administrador
+func Run(name string, args ...string) error {
+	name, args = Command(name, args...)
+	return utils.RunCommand(name, args...)
+}
+
+// RunCapture executa um comando como administrador e retorna a saída
+// padrão e de erro combinadas
+func RunCapture(name string, args ...string) (string, error) {
+	name, args = Command(name, args...)
+	return utils.RunCommandCapture(name, args...)
+}
+
+// Prepare lista os comandos privilegiados que serão executados e valida as
+// credenciais uma única vez antes de começar, com o prompt de senha visível.
+// Com sudo, as credenciais são renovadas em segundo plano até o fim da
+// execução, para que instalações longas não parem pedindo a senha. Chamadas
+// seguintes na mesma execução apenas listam os comandos ainda não listados.
+func Prepare(commands []string) error {
+	var pending []string
+	for _, command := range commands {
+		if !announced[command] {
+			pending = append(pending, command)
+		}
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	switch config.Escalation() {
+	case "auto", string(Sudo), string(Doas), string(None):
+	default:
+		return fmt.Errorf("estratégia de elevação inválida: %q (use auto, sudo, doas ou none)", config.Escalation())
+	}
+
+	strategy := Current()
+	if strategy == None {
+		return nil
+	}
+
+	color.Blue("🔐 Os comandos a seguir serão executados com %s:", strategy)
+	for _, command := range pending {
+		fmt.Printf("  • %s\n", command)
+	}
+
+	if !validated {
+		if _, err := exec.LookPath(string(strategy)); err != nil {
+			return fmt.Errorf("%s não está instalado; instale sudo ou doas, ou execute como root em um container (--escalation none)", strategy)
+		}
+
+		if err := validate(strategy); err != nil {
+			return fmt.Errorf("erro ao validar credenciais do %s: %w", strategy, err)
+		}
+		validated = true
+	}
+
+	for _, command := range pending {
+		announced[command] = true
+	}
+
+	if strategy == Sudo {
+		keepAlive.Do(func() {
+			go refresh()
+		})
+	}
+
+	return nil
+}
+
+// validate pede a senha uma vez com o terminal conectado. O doas não tem
+// equivalente ao "sudo -v"; com a opção persist em doas.conf a autenticação
+// de um comando vazio vale para os seguintes.
+func validate(strategy Strategy) error {
+	cmd := exec.Command("sudo", "-v")
+	if strategy == Doas {
+		cmd = exec.Command("doas", "true")
+	}
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// refresh renova o timestamp do sudo periodicamente sem pedir senha
+func refresh() {
+	ticker := time.NewTicker(keepAliveInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if err := utils.RunCommand("sudo", "-n", "-v"); err != nil {
+			return
+		}
+	}
+}
+
+// WriteFile grava content em path, um arquivo do sistema, com o modo
+// informado. Se o arquivo já tiver o mesmo conteúdo e modo nada é alterado;
+// retorna true quando o arquivo foi gravado.
+func WriteFile(path, content string, mode os.FileMode) (bool, error) {
+	if current, err := os.ReadFile(path); err == nil && string(current) == content {
+		if info, err := os.Stat(path); err == nil && info.Mode().Perm() == mode {
+			return false, nil
+		}
+	}
+
+	tmp, err := os.CreateTemp("", "setup-devops-"+filepath.Base(path)+"-")
+	if err != nil {
+		return false, fmt.Errorf("erro ao criar arquivo temporário: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.WriteString(content); err != nil {
+		_ = tmp.Close()
+		return false, fmt.Errorf("erro ao gravar arquivo temporário: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return false, err
+	}
+
+	if err := Run("mkdir", "-p", filepath.Dir(path)); err != nil {
+		return false, fmt.Errorf("erro ao criar %s: %w", filepath.Dir(path), err)
+	}
+	if err := Run("install", "-m", fmt.Sprintf("%04o", mode), tmp.Name(), path); err != nil {
+		return false, fmt.Errorf("erro ao gravar %s: %w", path, err)
+	}
+
+	return true, nil
+}
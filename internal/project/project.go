@@ -0,0 +1,99 @@
+// Package project encontra as versões de ferramentas fixadas por um
+// repositório, em um .setup-devops.yaml (seção versions) ou em um
+// .tool-versions compatível com o asdf, procurando do diretório atual para
+// cima.
+package project
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// Files são os arquivos procurados em cada diretório, em ordem de precedência
+var Files = []string{".setup-devops.yaml", ".tool-versions"}
+
+// Pin é uma versão fixada para uma ferramenta e o arquivo que a fixou
+type Pin struct {
+	Tool    string
+	Version string
+	File    string
+}
+
+// Find procura, de dir para cima, o arquivo mais próximo que fixa a versão
+// da ferramenta. A busca para antes do diretório home, onde o
+// ~/.setup-devops.yaml é a configuração do usuário e não de um projeto.
+// Retorna nil quando nenhum arquivo fixa a ferramenta.
+func Find(dir, tool string) (*Pin, error) {
+	home, _ := os.UserHomeDir()
+
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		if home != "" && dir == filepath.Clean(home) {
+			return nil, nil
+		}
+
+		for _, name := range Files {
+			path := filepath.Join(dir, name)
+			if _, err := os.Stat(path); err != nil {
+				continue
+			}
+
+			version, err := readVersion(path, tool)
+			if err != nil {
+				return nil, err
+			}
+			if version != "" {
+				return &Pin{Tool: tool, Version: version, File: path}, nil
+			}
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, nil
+		}
+		dir = parent
+	}
+}
+
+// readVersion lê a versão da ferramenta fixada no arquivo ("" se ausente)
+func readVersion(path, tool string) (string, error) {
+	if filepath.Base(path) == ".tool-versions" {
+		return readToolVersions(path, tool)
+	}
+
+	v := viper.New()
+	v.SetConfigFile(path)
+	v.SetConfigType("yaml")
+	if err := v.ReadInConfig(); err != nil {
+		return "", fmt.Errorf("erro ao ler %s: %w", path, err)
+	}
+	return v.GetString("versions." + tool), nil
+}
+
+// readToolVersions lê o formato do asdf: "<ferramenta> <versão> [alternativas...]"
+// por linha, com comentários iniciados por #; vale a primeira versão
+func readToolVersions(path, tool string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("erro ao ler %s: %w", path, err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		if fields := strings.Fields(line); len(fields) >= 2 && fields[0] == tool {
+			return fields[1], nil
+		}
+	}
+	return "", scanner.Err()
+}
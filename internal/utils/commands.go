@@ -2,6 +2,7 @@ package utils
 
 import (
 	"os/exec"
+	"strings"
 )
 
 // RunCommand executa um comando do sistema
@@ -12,7 +13,39 @@ func RunCommand(name string, args ...string) error {
 	return cmd.Run()
 }
 
+// RunCommandInDir executa um comando do sistema no diretório informado
+func RunCommandInDir(dir, name string, args ...string) error {
+	cmd := exec.Command(name, args...)
+	cmd.Dir = dir
+	cmd.Stdout = nil
+	cmd.Stderr = nil
+	return cmd.Run()
+}
+
+// RunCommandOutput executa um comando do sistema e retorna sua saída padrão
+func RunCommandOutput(name string, args ...string) (string, error) {
+	output, err := exec.Command(name, args...).Output()
+	return string(output), err
+}
+
 // RunCommandSilent executa um comando do sistema sem retornar erro (para operações de limpeza)
 func RunCommandSilent(name string, args ...string) {
 	_ = RunCommand(name, args...)
 }
+
+// RunCommandCapture executa um comando do sistema e retorna a saída padrão e
+// de erro combinadas, para que falhas possam ser analisadas e relatadas
+func RunCommandCapture(name string, args ...string) (string, error) {
+	output, err := exec.Command(name, args...).CombinedOutput()
+	return string(output), err
+}
+
+// LastLines retorna as últimas linhas da saída de um comando, onde as
+// ferramentas costumam descrever o erro
+func LastLines(output string) string {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	if len(lines) > 3 {
+		lines = lines[len(lines)-3:]
+	}
+	return strings.Join(lines, "\n")
+}
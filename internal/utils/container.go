@@ -0,0 +1,38 @@
+package utils
+
+import (
+	"os"
+	"strings"
+)
+
+// containerCgroupMarkers são trechos de /proc/1/cgroup que indicam que o
+// processo init pertence a um container
+var containerCgroupMarkers = []string{"docker", "kubepods", "containerd", "lxc", "libpod"}
+
+// InContainer verifica se o processo roda dentro de um container (Docker,
+// Podman, Kubernetes, devcontainers), onde normalmente já se é root, não há
+// sudo e nenhum init gerencia serviços
+func InContainer() bool {
+	for _, marker := range []string{"/.dockerenv", "/run/.containerenv"} {
+		if _, err := os.Stat(marker); err == nil {
+			return true
+		}
+	}
+
+	// Variável definida pelo systemd-nspawn, Podman e LXC
+	if os.Getenv("container") != "" {
+		return true
+	}
+
+	data, err := os.ReadFile("/proc/1/cgroup")
+	if err != nil {
+		return false
+	}
+	cgroup := string(data)
+	for _, marker := range containerCgroupMarkers {
+		if strings.Contains(cgroup, marker) {
+			return true
+		}
+	}
+	return false
+}
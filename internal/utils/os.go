@@ -1,108 +1,262 @@
 package utils
 
 import (
+	"bufio"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"runtime"
+	"strconv"
 	"strings"
 )
 
-// OSType representa o tipo de sistema operacional
+// OSType representa a família de sistema operacional usada pelos instaladores
 type OSType string
 
 const (
-	Ubuntu OSType = "ubuntu"
-	CentOS OSType = "centos"
-	MacOS  OSType = "macos"
+	Ubuntu      OSType = "ubuntu"
+	Debian      OSType = "debian"
+	CentOS      OSType = "centos"
+	Fedora      OSType = "fedora"
+	AmazonLinux OSType = "amazonlinux"
+	Arch        OSType = "arch"
+	Alpine      OSType = "alpine"
+	OpenSUSE    OSType = "opensuse"
+	MacOS       OSType = "macos"
 )
 
-// DetectOS detecta o sistema operacional
+// IsLinux verifica se a família é uma distribuição Linux
+func (t OSType) IsLinux() bool {
+	return t != "" && t != MacOS
+}
+
+// OSInfo descreve o sistema operacional detectado
+type OSInfo struct {
+	// Type é a família usada para escolher o instalador
+	Type OSType
+	// ID é o identificador da distribuição (ID do os-release, ex: linuxmint, rocky) ou "macos"
+	ID string
+	// IDLike lista as distribuições das quais esta deriva (ID_LIKE do os-release)
+	IDLike []string
+	// Name é o nome legível do sistema (PRETTY_NAME do os-release)
+	Name string
+	// VersionID é a versão do sistema (ex: 22.04, 9.3, 14.2)
+	VersionID string
+	// Codename é o codinome usado nos repositórios apt (ex: jammy)
+	Codename string
+	// Arch é a arquitetura no formato do Go (amd64, arm64)
+	Arch string
+	// WSL é a versão do Windows Subsystem for Linux (0 fora do WSL)
+	WSL int
+}
+
+// osReleasePaths são os locais padrão do os-release, em ordem de preferência
+var osReleasePaths = []string{"/etc/os-release", "/usr/lib/os-release"}
+
+// Versões mínimas suportadas por ID de distribuição
+var minimumVersions = map[string]string{
+	"ubuntu":        "20.04",
+	"debian":        "11",
+	"rhel":          "8",
+	"centos":        "8",
+	"rocky":         "8",
+	"almalinux":     "8",
+	"fedora":        "38",
+	"amzn":          "2",
+	"alpine":        "3.17",
+	"opensuse-leap": "15.4",
+	"macos":         "12",
+}
+
+// DetectOS detecta a família do sistema operacional
 func DetectOS() (OSType, error) {
+	info, err := GetOSInfo()
+	if err != nil {
+		return "", err
+	}
+	return info.Type, nil
+}
+
+// GetOSInfo retorna informações detalhadas do sistema operacional
+func GetOSInfo() (*OSInfo, error) {
 	switch runtime.GOOS {
 	case "linux":
 		return detectLinuxDistro()
 	case "darwin":
-		return MacOS, nil
+		return detectMacOS()
 	default:
-		return "", fmt.Errorf("sistema operacional não suportado: %s", runtime.GOOS)
+		return nil, fmt.Errorf("sistema operacional não suportado: %s", runtime.GOOS)
 	}
 }
 
-// detectLinuxDistro detecta a distribuição Linux
-func detectLinuxDistro() (OSType, error) {
-	// Verificar se é Ubuntu/Debian
-	if _, err := exec.LookPath("apt-get"); err == nil {
-		return Ubuntu, nil
+// detectLinuxDistro detecta a distribuição Linux a partir do os-release
+func detectLinuxDistro() (*OSInfo, error) {
+	var fields map[string]string
+	var lastErr error
+	for _, path := range osReleasePaths {
+		f, err := os.Open(path)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		fields, err = ParseOSRelease(f)
+		_ = f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("erro ao ler %s: %w", path, err)
+		}
+		break
+	}
+	if fields == nil {
+		return nil, fmt.Errorf("não foi possível identificar a distribuição Linux: %w", lastErr)
+	}
+
+	info := &OSInfo{
+		ID:        strings.ToLower(fields["ID"]),
+		IDLike:    strings.Fields(strings.ToLower(fields["ID_LIKE"])),
+		Name:      fields["PRETTY_NAME"],
+		VersionID: fields["VERSION_ID"],
+		Codename:  fields["VERSION_CODENAME"],
+		Arch:      runtime.GOARCH,
+		WSL:       WSLVersion(),
 	}
 
-	// Verificar se é CentOS/RHEL
-	if _, err := exec.LookPath("yum"); err == nil {
-		return CentOS, nil
+	// Derivados do Ubuntu (Mint, Pop!_OS) usam os repositórios do Ubuntu base
+	if codename := fields["UBUNTU_CODENAME"]; codename != "" {
+		info.Codename = codename
 	}
 
-	if _, err := exec.LookPath("dnf"); err == nil {
-		return CentOS, nil
+	info.Type = linuxFamily(info)
+	if info.Type == "" {
+		name := info.Name
+		if name == "" {
+			name = info.ID
+		}
+		return nil, fmt.Errorf("distribuição Linux não suportada: %s", name)
 	}
 
-	return "", fmt.Errorf("distribuição Linux não suportada")
+	return info, nil
 }
 
-// IsRoot verifica se o processo está rodando como root
-func IsRoot() bool {
-	return os.Geteuid() == 0
+// linuxFamily escolhe a família a partir do ID e do ID_LIKE da distribuição.
+// O ID é verificado primeiro, de forma que derivados com ID_LIKE amplo (ex:
+// Amazon Linux com ID_LIKE="centos rhel fedora") mantêm a própria família.
+func linuxFamily(info *OSInfo) OSType {
+	for _, id := range append([]string{info.ID}, info.IDLike...) {
+		switch id {
+		case "ubuntu":
+			return Ubuntu
+		case "debian":
+			return Debian
+		case "rhel", "centos", "rocky", "almalinux":
+			return CentOS
+		case "fedora":
+			return Fedora
+		case "amzn":
+			return AmazonLinux
+		case "arch":
+			return Arch
+		case "alpine":
+			return Alpine
+		case "opensuse", "opensuse-leap", "opensuse-tumbleweed", "sles", "suse":
+			return OpenSUSE
+		}
+	}
+	return ""
 }
 
-// GetOSInfo retorna informações detalhadas do sistema operacional
-func GetOSInfo() (map[string]string, error) {
-	info := make(map[string]string)
+// detectMacOS obtém as informações do macOS
+func detectMacOS() (*OSInfo, error) {
+	info := &OSInfo{
+		Type: MacOS,
+		ID:   "macos",
+		Arch: runtime.GOARCH,
+	}
 
-	osType, err := DetectOS()
-	if err != nil {
-		return nil, err
+	if version, err := getMacOSVersion(); err == nil {
+		info.VersionID = version
+		info.Name = "macOS " + version
 	}
 
-	info["type"] = string(osType)
-	info["arch"] = runtime.GOARCH
-	info["goos"] = runtime.GOOS
+	return info, nil
+}
+
+// ParseOSRelease lê um arquivo no formato os-release (CHAVE=valor)
+func ParseOSRelease(r io.Reader) (map[string]string, error) {
+	fields := make(map[string]string)
 
-	// Informações específicas por OS
-	switch osType {
-	case Ubuntu:
-		if version, err := getUbuntuVersion(); err == nil {
-			info["version"] = version
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
 		}
-	case CentOS:
-		if version, err := getCentOSVersion(); err == nil {
-			info["version"] = version
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
 		}
-	case MacOS:
-		if version, err := getMacOSVersion(); err == nil {
-			info["version"] = version
+
+		if unquoted, err := strconv.Unquote(value); err == nil {
+			value = unquoted
+		} else {
+			value = strings.Trim(value, `"'`)
 		}
+		fields[key] = value
 	}
 
-	return info, nil
+	return fields, scanner.Err()
 }
 
-// getUbuntuVersion obtém a versão do Ubuntu
-func getUbuntuVersion() (string, error) {
-	cmd := exec.Command("lsb_release", "-r", "-s")
-	output, err := cmd.Output()
-	if err != nil {
-		return "", err
+// String retorna uma descrição legível do sistema
+func (i *OSInfo) String() string {
+	if i.Name != "" {
+		return i.Name
 	}
-	return strings.TrimSpace(string(output)), nil
+	return strings.TrimSpace(string(i.Type) + " " + i.VersionID)
 }
 
-// getCentOSVersion obtém a versão do CentOS
-func getCentOSVersion() (string, error) {
-	cmd := exec.Command("cat", "/etc/redhat-release")
-	output, err := cmd.Output()
-	if err != nil {
-		return "", err
+// CheckMinimumVersion verifica se a versão do sistema atende o mínimo suportado
+func (i *OSInfo) CheckMinimumVersion() error {
+	minimum, ok := minimumVersions[i.ID]
+	if !ok || i.VersionID == "" {
+		return nil
 	}
-	return strings.TrimSpace(string(output)), nil
+
+	if CompareVersions(i.VersionID, minimum) < 0 {
+		return fmt.Errorf("%s não é suportado (mínimo: %s %s)", i.String(), i.ID, minimum)
+	}
+	return nil
+}
+
+// CompareVersions compara versões numéricas separadas por ponto (ex: 20.04, 8.9).
+// Retorna -1 se a < b, 0 se iguais e 1 se a > b.
+func CompareVersions(a, b string) int {
+	partsA := strings.Split(a, ".")
+	partsB := strings.Split(b, ".")
+
+	for i := 0; i < len(partsA) || i < len(partsB); i++ {
+		var numA, numB int
+		if i < len(partsA) {
+			numA, _ = strconv.Atoi(partsA[i])
+		}
+		if i < len(partsB) {
+			numB, _ = strconv.Atoi(partsB[i])
+		}
+
+		if numA != numB {
+			if numA < numB {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// IsRoot verifica se o processo está rodando como root
+func IsRoot() bool {
+	return os.Geteuid() == 0
 }
 
 // getMacOSVersion obtém a versão do macOS
@@ -0,0 +1,122 @@
+package utils
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// WSLVersion retorna 1 ou 2 quando o processo roda no Windows Subsystem for
+// Linux e 0 caso contrário. A detecção usa WSL_DISTRO_NAME e a assinatura do
+// kernel da Microsoft em /proc/version.
+func WSLVersion() int {
+	data, _ := os.ReadFile("/proc/version")
+	version := string(data)
+
+	isWSL := os.Getenv("WSL_DISTRO_NAME") != "" || strings.Contains(strings.ToLower(version), "microsoft")
+	if !isWSL {
+		return 0
+	}
+
+	// WSL1 roda sobre o kernel do Windows ("4.4.0-19041-Microsoft");
+	// o WSL2 usa um kernel Linux real ("5.15.90.1-microsoft-standard-WSL2")
+	if strings.Contains(version, "Microsoft") && !strings.Contains(version, "WSL2") {
+		return 1
+	}
+	return 2
+}
+
+// WSLDistro retorna o nome da distribuição no WSL (ex: Ubuntu-22.04)
+func WSLDistro() string {
+	return os.Getenv("WSL_DISTRO_NAME")
+}
+
+// IsWindowsPath verifica se o caminho está em um drive do Windows montado
+// pelo WSL (ex: /mnt/c/Program Files/...)
+func IsWindowsPath(path string) bool {
+	rest, ok := strings.CutPrefix(path, "/mnt/")
+	if !ok || rest == "" {
+		return false
+	}
+	drive, _, _ := strings.Cut(rest, "/")
+	return len(drive) == 1 && drive[0] >= 'a' && drive[0] <= 'z'
+}
+
+// ignoredPathDirs são diretórios do PATH ignorados por LookPath
+var ignoredPathDirs []string
+
+// IgnorePathDir faz LookPath ignorar o diretório, cujos executáveis não são
+// as ferramentas em si (ex: os shims da CLI)
+func IgnorePathDir(dir string) {
+	ignoredPathDirs = append(ignoredPathDirs, filepath.Clean(dir))
+}
+
+// LookPath procura o comando no PATH como exec.LookPath, mas no WSL ignora os
+// diretórios do Windows, cujos executáveis não são as ferramentas do Linux,
+// e sempre ignora os diretórios registrados com IgnorePathDir
+func LookPath(command string) (string, error) {
+	wsl := WSLVersion() > 0
+	if !wsl && len(ignoredPathDirs) == 0 {
+		return exec.LookPath(command)
+	}
+
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		if dir == "" || (wsl && IsWindowsPath(dir)) || ignoredPathDir(dir) {
+			continue
+		}
+		path := filepath.Join(dir, command)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() && info.Mode()&0o111 != 0 {
+			return path, nil
+		}
+	}
+	return "", &exec.Error{Name: command, Err: exec.ErrNotFound}
+}
+
+// ignoredPathDir verifica se o diretório foi registrado com IgnorePathDir
+func ignoredPathDir(dir string) bool {
+	dir = filepath.Clean(dir)
+	for _, ignored := range ignoredPathDirs {
+		if dir == ignored {
+			return true
+		}
+	}
+	return false
+}
+
+// WindowsShadow retorna o executável do Windows que o PATH resolve para o
+// comando, quando ele tem precedência sobre (ou substitui) a versão do Linux
+func WindowsShadow(command string) (string, bool) {
+	path, err := exec.LookPath(command)
+	if err != nil || !IsWindowsPath(path) {
+		return "", false
+	}
+	return path, true
+}
+
+// DockerDesktopIntegration verifica se a integração WSL do Docker Desktop
+// está ativa nesta distribuição, fornecendo o comando docker
+func DockerDesktopIntegration() bool {
+	if _, err := os.Stat("/mnt/wsl/docker-desktop/cli-tools"); err == nil {
+		return true
+	}
+
+	path, err := LookPath("docker")
+	if err != nil {
+		return false
+	}
+	resolved, err := filepath.EvalSymlinks(path)
+	return err == nil && strings.Contains(resolved, "docker-desktop")
+}
+
+// DockerDesktopOnWindows verifica se o Docker Desktop está instalado no
+// Windows, mesmo sem a integração com esta distribuição
+func DockerDesktopOnWindows() bool {
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		if IsWindowsPath(dir) && strings.Contains(dir, "Docker/Docker/resources/bin") {
+			return true
+		}
+	}
+	_, err := os.Stat("/mnt/c/Program Files/Docker/Docker")
+	return err == nil
+}